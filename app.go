@@ -8,9 +8,14 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"golang.org/x/sync/errgroup"
 )
@@ -18,72 +23,248 @@ import (
 // App represents a struct that provides functionality for interacting with the AWS services.
 type App struct {
 	accountID   string
+	assumeRole  AssumeRole
+	baseCfg     aws.Config
+	check       []RunnerType
+	detectors   []SecretDetector
+	endpoints   Endpoints
+	limits      *rateLimits
+	orgClient   organizationsClient
+	regions     []string
 	Runners     []Runner
+	scoring     *ScoringRules
+	securityHub func(region string) securityHubClient
 	stsClient   stsClient
 	workerLimit int
 }
 
 // NewApp initializes and returns a new App with the given settings and runners.
+// Without regions, the default region of the loaded AWS configuration is scanned.
 func NewApp(
 	ctx context.Context,
 	check []RunnerType,
 	regions []string,
 	workerLimit int,
+	optFns ...Option,
 ) (*App, error) {
-	if len(regions) == 0 {
-		return nil, ErrEmptyRegion
-	}
-
 	if len(check) == 0 {
 		return nil, ErrEmptyCheck
 	}
 
-	regions = uniqRegions(regions)
-
 	if workerLimit < 1 {
 		workerLimit = 1
 	}
 
-	baseCfg, err := config.LoadDefaultConfig(ctx)
+	opts := options{
+		assumeRole: AssumeRole{
+			ExternalID:  "",
+			RoleARN:     "",
+			SessionName: "",
+		},
+		credentials: CredentialSource{
+			Profile:              "",
+			ConfigFile:           "",
+			CredentialsFile:      "",
+			WebIdentityTokenFile: "",
+			WebIdentityRoleARN:   "",
+		},
+		detectors:       nil,
+		discoverRegions: false,
+		endpoints: Endpoints{
+			URL:      "",
+			Services: nil,
+		},
+		rateLimit: 0,
+		retry: RetryPolicy{
+			Mode:        "",
+			MaxAttempts: 0,
+		},
+		scoring: DefaultScoringRules(),
+	}
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	err := opts.credentials.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	retryMode, err := parseRetryMode(opts.retry.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	loadOptions := opts.credentials.loadOptions()
+	if retryMode != "" {
+		loadOptions = append(loadOptions, config.WithRetryMode(retryMode))
+	}
+
+	if opts.retry.MaxAttempts > 0 {
+		loadOptions = append(loadOptions, config.WithRetryMaxAttempts(opts.retry.MaxAttempts))
+	}
+
+	baseCfg, err := config.LoadDefaultConfig(ctx, loadOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load aws config, %w", err)
 	}
 
-	stsCfg := baseCfg.Copy()
+	// without regions, scan the default region of the profile or environment
+	if len(regions) == 0 && baseCfg.Region != "" {
+		regions = []string{baseCfg.Region}
+	}
+
+	if len(regions) == 0 {
+		return nil, ErrEmptyRegion
+	}
+
+	regions = uniqStrings(regions)
+
+	err = checkPartition(regions)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.partition != "" && RegionPartition(regions[0]) != opts.partition {
+		return nil, fmt.Errorf(
+			"%w: %s is not in %s",
+			ErrRegionPartition,
+			regions[0],
+			opts.partition,
+		)
+	}
+
+	if opts.credentials.WebIdentityTokenFile != "" {
+		webIdentityCfg := opts.endpoints.config(baseCfg, serviceSTS)
+		webIdentityCfg.Region = regions[0]
+
+		baseCfg.Credentials = opts.credentials.webIdentityCredentials(webIdentityCfg)
+	}
+
+	if opts.discoverRegions {
+		ec2Cfg := opts.endpoints.config(baseCfg, serviceEC2)
+		if ec2Cfg.Region == "" {
+			ec2Cfg.Region = regions[0]
+		}
+
+		regions = discoverRegions(ctx, ec2.NewFromConfig(ec2Cfg), regions)
+	}
+
+	// STS and Organizations are called in the first region, so their endpoints match the partition of the scan
+	stsCfg := opts.endpoints.config(baseCfg, serviceSTS)
 	stsCfg.Region = regions[0]
 
-	runners := setUpRunners(baseCfg, check, regions)
+	orgCfg := opts.endpoints.config(baseCfg, serviceOrganizations)
+	orgCfg.Region = regions[0]
+
+	limits := newRateLimits(opts.rateLimit)
+	runners := setUpRunners(baseCfg, check, regions, opts.detectors, &opts.endpoints, limits)
 
 	return &App{
 		accountID:   "",
+		assumeRole:  opts.assumeRole,
+		baseCfg:     baseCfg,
+		check:       check,
+		detectors:   opts.detectors,
+		endpoints:   opts.endpoints,
+		limits:      limits,
+		orgClient:   organizations.NewFromConfig(orgCfg),
+		regions:     regions,
 		Runners:     runners,
+		scoring:     opts.scoring,
+		securityHub: securityHubClients(opts.endpoints.config(baseCfg, serviceSecurityHub)),
 		stsClient:   sts.NewFromConfig(stsCfg),
 		workerLimit: workerLimit,
 	}, nil
 }
 
 // setUpRunners initializes and returns a list of runners based on the specified configuration, checks, and regions.
-func setUpRunners(baseCfg aws.Config, check []RunnerType, regions []string) []Runner {
+// SSM document runners search document content with the detectors, when there are any,
+// and every runner sends its requests to the endpoint of its service, within the rate limits of the service.
+func setUpRunners(
+	baseCfg aws.Config,
+	check []RunnerType,
+	regions []string,
+	detectors []SecretDetector,
+	endpoints *Endpoints,
+	limits *rateLimits,
+) []Runner {
 	runners := make([]Runner, 0)
 
-	for _, region := range regions {
+	for idx, region := range regions {
 		cfg := baseCfg.Copy()
 		cfg.Region = region
 
+		serviceConfig := func(service string) aws.Config {
+			return limits.config(endpoints.config(cfg, service), service)
+		}
+
 		for _, scan := range check {
 			switch scan {
 			case ImageAMI:
-				runners = append(runners, NewAMIScan(cfg))
+				runners = append(runners, NewAMIScan(serviceConfig(serviceEC2)))
 			case SnapshotEBS:
-				runners = append(runners, NewEBSSnapshotRunner(cfg))
+				runners = append(runners, NewEBSSnapshotRunner(serviceConfig(serviceEC2)))
 			case SnapshotRDS:
 				runners = append(
 					runners,
-					NewRDSSnapshotRunner(cfg, isRDSSnapshotOwner),
-					NewRDSClusterSnapshotRunner(cfg, isRDSClusterSnapshotOwner),
+					NewRDSSnapshotRunner(serviceConfig(serviceRDS), isRDSSnapshotOwner),
+					NewRDSClusterSnapshotRunner(
+						serviceConfig(serviceRDS),
+						isRDSClusterSnapshotOwner,
+					),
 				)
 			case DocumentSSM:
-				runners = append(runners, NewSSMDocumentScan(cfg, isSSMDocumentOwner))
+				runners = append(
+					runners,
+					NewSSMDocumentScan(
+						serviceConfig(serviceSSM),
+						isSSMDocumentOwner,
+						detectors...,
+					),
+				)
+			case LayerLambda:
+				runners = append(
+					runners,
+					NewLambdaLayerScan(serviceConfig(serviceLambda), isLambdaLayerOwner),
+				)
+			case SnapshotRedshift:
+				runners = append(
+					runners,
+					NewRedshiftSnapshotRunner(
+						serviceConfig(serviceRedshift),
+						isRedshiftSnapshotOwner,
+					),
+				)
+			case SnapshotDocDB:
+				runners = append(
+					runners,
+					NewDocDBClusterSnapshotRunner(
+						serviceConfig(serviceRDS),
+						isRDSClusterSnapshotOwner,
+					),
+				)
+			case SnapshotNeptune:
+				runners = append(
+					runners,
+					NewNeptuneClusterSnapshotRunner(
+						serviceConfig(serviceRDS),
+						isRDSClusterSnapshotOwner,
+					),
+				)
+			case RepositoryECR:
+				runners = append(
+					runners,
+					NewECRRepositoryScan(serviceConfig(serviceECR), isECRRepositoryOwner),
+				)
+
+				// ECR Public is a global service of the aws partition, scan it only once.
+				if idx == 0 && RegionPartition(region) == PartitionAWS {
+					runners = append(
+						runners,
+						NewECRPublicRepositoryScan(serviceConfig(serviceECRPublic)),
+					)
+				}
 			}
 		}
 	}
@@ -91,30 +272,80 @@ func setUpRunners(baseCfg aws.Config, check []RunnerType, regions []string) []Ru
 	return runners
 }
 
-// Run scans the target using all runners and returns the results or an error.
-func (a *App) Run(ctx context.Context, target string) ([]Result, error) { //nolint:funlen
+// Run scans the target using all runners and returns a report with the results.
+// A failing runner does not abort the scan, its error is recorded in the report instead.
+// EBS snapshots backing a reported AMI are linked to it through their ParentImage.
+func (a *App) Run(ctx context.Context, target string) (*Report, error) {
+	return a.RunMany(ctx, []string{target})
+}
+
+// RunMany scans every target account using all runners and returns a single report with the results.
+// All targets share the same worker limit, and each result records the account it was found under.
+// EBS snapshots backing a reported AMI are linked to it through their ParentImage.
+func (a *App) RunMany(ctx context.Context, targets []string) (*Report, error) {
+	var results []Result
+
+	report, err := a.RunStream(ctx, targets, func(batch []Result) {
+		results = append(results, batch...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.Results = results
+	report.LinkImageSnapshots()
+
+	return report, nil
+}
+
+// ResultHandler receives the results of a single runner as soon as it finishes.
+type ResultHandler func(results []Result)
+
+// RunStream scans every target account like RunMany, but passes the results of each runner to onResults
+// as soon as the runner finishes. Calls to onResults are serialized, and the returned report only holds failures.
+// Streamed results are not linked to their parent AMI, since the AMI may be reported after its snapshots,
+// see Report.LinkImageSnapshots.
+func (a *App) RunStream( //nolint:funlen
+	ctx context.Context,
+	targets []string,
+	onResults ResultHandler,
+) (*Report, error) {
+	targets, err := a.resolveTargets(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, setUpFailures := a.prepareJobs(ctx, targets)
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+	}
+
+	now := time.Now()
+
 	group, gCtx := errgroup.WithContext(ctx)
 	group.SetLimit(a.workerLimit)
 
-	if strings.EqualFold(target, "self") {
-		slog.Debug("replacing self with account ID",
-			slog.String("accountID", a.accountID),
-		)
+	buffer := make(chan []Result, len(jobs))
+	failures := make(chan Failure, len(jobs))
+	streamed := make(chan struct{})
 
-		target = a.accountID
-	}
+	go func() {
+		defer close(streamed)
+
+		for batch := range buffer {
+			onResults(batch)
+		}
+	}()
+
+	for _, job := range jobs {
+		target, scanRunner := job.target, job.runner
+		scanCtx := withCaller(ctx, job.caller)
 
-	buffer := make(chan []Result, len(a.Runners))
-	for _, scanRunner := range a.Runners {
 		group.Go(func() error {
 			select {
 			case <-gCtx.Done():
 				return gCtx.Err()
 			default:
-				if target == "" {
-					return fmt.Errorf("%w: target account ID is required", ErrEmptyTarget)
-				}
-
 				slog.Debug(
 					"starting scan",
 					slog.String("region", scanRunner.getRegion()),
@@ -122,14 +353,25 @@ func (a *App) Run(ctx context.Context, target string) ([]Result, error) { //noli
 					slog.String("type", scanRunner.RunType().String()),
 				)
 
-				scanResults, err := scanRunner.Scan(ctx, target)
+				scanResults, err := scanRunner.Scan(scanCtx, target)
 				if err != nil {
-					return fmt.Errorf(
-						"failed to scan %s, in region %s, %w",
-						scanRunner.RunType().String(),
-						scanRunner.getRegion(),
-						err,
+					if ctx.Err() != nil {
+						return fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+					}
+
+					failure := newFailure(scanRunner, target, err)
+
+					slog.Warn("failed to scan",
+						slog.String("class", string(failure.Class)),
+						slog.String("error", failure.Error),
+						slog.String("region", failure.Region),
+						slog.String("target", target),
+						slog.String("type", failure.RType.String()),
 					)
+
+					failures <- failure
+
+					return nil
 				}
 
 				slog.Debug("finished scan",
@@ -139,6 +381,18 @@ func (a *App) Run(ctx context.Context, target string) ([]Result, error) { //noli
 					slog.String("type", scanRunner.RunType().String()),
 				)
 
+				if len(scanResults) == 0 {
+					return nil
+				}
+
+				for i := range scanResults {
+					scanResults[i].Account = target
+
+					if a.scoring != nil {
+						scanResults[i].Severity = a.scoring.Severity(&scanResults[i], now)
+					}
+				}
+
 				buffer <- scanResults
 			}
 
@@ -146,19 +400,189 @@ func (a *App) Run(ctx context.Context, target string) ([]Result, error) { //noli
 		})
 	}
 
-	err := group.Wait()
+	err = group.Wait()
+
+	close(buffer)
+	<-streamed
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to run all checks, %w", err)
 	}
 
-	close(buffer)
+	close(failures)
+
+	report := &Report{
+		Results:    nil,
+		Failures:   setUpFailures,
+		Suppressed: nil,
+		Diff:       nil,
+	}
+
+	for failure := range failures {
+		report.Failures = append(report.Failures, failure)
+	}
+
+	return report, nil
+}
+
+// scanJob pairs a runner with the target account it scans and the account it scans from.
+type scanJob struct {
+	caller string
+	runner Runner
+	target string
+}
+
+// prepareJobs pairs every target with the runners used to scan it.
+// When a role is configured, runners use credentials assumed in the target account,
+// and a target whose role cannot be assumed is reported as failed for each runner.
+func (a *App) prepareJobs(ctx context.Context, targets []string) ([]scanJob, []Failure) {
+	var (
+		jobs     []scanJob
+		failures []Failure
+	)
+
+	for _, target := range targets {
+		caller := a.accountID
+		runners := a.Runners
+
+		if a.assumeRole.RoleARN != "" {
+			cfg, err := a.targetConfig(ctx, target)
+			if err != nil {
+				slog.Warn("failed to assume role",
+					slog.String("error", err.Error()),
+					slog.String("target", target),
+				)
 
-	var output []Result
-	for item := range buffer {
-		output = append(output, item...)
+				for _, scanRunner := range a.Runners {
+					failures = append(failures, newFailure(scanRunner, target, err))
+				}
+
+				continue
+			}
+
+			caller = target
+			runners = setUpRunners(cfg, a.check, a.regions, a.detectors, &a.endpoints, a.limits)
+		}
+
+		for _, scanRunner := range runners {
+			jobs = append(jobs, scanJob{
+				caller: caller,
+				runner: scanRunner,
+				target: target,
+			})
+		}
 	}
 
-	return output, nil
+	return jobs, failures
+}
+
+// targetConfig returns a copy of the base config with credentials of the role assumed in the target account.
+func (a *App) targetConfig(ctx context.Context, target string) (aws.Config, error) {
+	roleARN := strings.ReplaceAll(a.assumeRole.RoleARN, AccountPlaceholder, target)
+
+	provider := aws.NewCredentialsCache(
+		stscreds.NewAssumeRoleProvider(
+			a.stsClient,
+			roleARN,
+			func(o *stscreds.AssumeRoleOptions) {
+				o.RoleSessionName = a.assumeRole.SessionName
+
+				if a.assumeRole.ExternalID != "" {
+					o.ExternalID = aws.String(a.assumeRole.ExternalID)
+				}
+			},
+		),
+	)
+
+	_, err := provider.Retrieve(ctx)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to assume role %s, %w", roleARN, err)
+	}
+
+	cfg := a.baseCfg.Copy()
+	cfg.Credentials = provider
+
+	return cfg, nil
+}
+
+// resolveTargets replaces "self" with the caller account ID and removes duplicated targets.
+func (a *App) resolveTargets(targets []string) ([]string, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w: at least one target account ID is required", ErrEmptyTarget)
+	}
+
+	resolved := make([]string, 0, len(targets))
+
+	for _, target := range targets {
+		if strings.EqualFold(target, "self") {
+			slog.Debug("replacing self with account ID",
+				slog.String("accountID", a.accountID),
+			)
+
+			target = a.accountID
+		}
+
+		if target == "" {
+			return nil, fmt.Errorf("%w: target account ID is required", ErrEmptyTarget)
+		}
+
+		resolved = append(resolved, target)
+	}
+
+	return uniqStrings(resolved), nil
+}
+
+// ListOrganizationAccounts returns the IDs of all active accounts in the caller's AWS Organization.
+func (a *App) ListOrganizationAccounts(ctx context.Context) ([]string, error) {
+	var accounts []string
+
+	paginator := organizations.NewListAccountsPaginator(
+		a.orgClient,
+		&organizations.ListAccountsInput{
+			MaxResults: nil,
+			NextToken:  nil,
+		},
+	)
+	for paginator.HasMorePages() {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+		}
+
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organization accounts, %w", err)
+		}
+
+		for _, account := range page.Accounts {
+			if account.State != orgTypes.AccountStateActive {
+				slog.Debug("skipping inactive account",
+					slog.String("accountID", *account.Id),
+					slog.String("state", string(account.State)),
+				)
+
+				continue
+			}
+
+			accounts = append(accounts, *account.Id)
+		}
+	}
+
+	if len(accounts) == 0 {
+		return nil, ErrEmptyOrganization
+	}
+
+	return accounts, nil
+}
+
+// Throttles returns the number of throttling errors returned to the runners, keyed by service/region.
+// Every attempt is counted, including the ones that succeeded after a retry.
+func (a *App) Throttles() map[string]int {
+	return a.limits.throttleCounts()
+}
+
+// AccountID returns the AWS account ID set by GetAccountID.
+func (a *App) AccountID() string {
+	return a.accountID
 }
 
 // GetAccountID fetches the AWS account ID and sets it in App.
@@ -174,5 +598,10 @@ func (a *App) GetAccountID(ctx context.Context) error {
 
 	a.accountID = *output.Account
 
+	slog.Debug("resolved caller identity",
+		slog.String("accountID", a.accountID),
+		slog.String("arn", aws.ToString(output.Arn)),
+	)
+
 	return nil
 }
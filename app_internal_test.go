@@ -7,8 +7,14 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	orgTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/smithy-go"
 )
 
 func TestApp_Run(t *testing.T) {
@@ -29,12 +35,38 @@ func TestApp_Run(t *testing.T) {
 		},
 	}
 
+	failingRunners := []Runner{
+		&EBSSnapshotScan{
+			baseRunner: baseRunner{
+				region:     "eu-west-1",
+				runnerType: SnapshotEBS,
+			},
+			client: &mockEBSSnapshotClient{
+				mockSnapshot:    nil,
+				mockSnapshotErr: nil,
+			},
+		},
+		&EBSSnapshotScan{
+			baseRunner: baseRunner{
+				region:     "ap-east-1",
+				runnerType: SnapshotEBS,
+			},
+			client: &mockEBSSnapshotClient{
+				mockSnapshot: nil,
+				mockSnapshotErr: &smithy.GenericAPIError{
+					Code:    "OptInRequired",
+					Message: "region is disabled",
+				},
+			},
+		},
+	}
+
 	tests := []struct {
 		name    string
 		ctx     context.Context //nolint:containedctx
 		runners []Runner
 		target  string
-		want    []Result
+		want    *Report
 		wantErr bool
 	}{
 		{
@@ -42,7 +74,27 @@ func TestApp_Run(t *testing.T) {
 			ctx:     t.Context(),
 			runners: mockRunners,
 			target:  "42",
-			want:    nil,
+			want:    &Report{},
+			wantErr: false,
+		},
+		{
+			name:    "partial results when one runner fails",
+			ctx:     t.Context(),
+			runners: failingRunners,
+			target:  "42",
+			want: &Report{
+				Results: nil,
+				Failures: []Failure{
+					{
+						Account: "42",
+						Class:   FailureRegionDisabled,
+						Code:    "OptInRequired",
+						Error:   "failed to fetch snapshots, api error OptInRequired: region is disabled",
+						Region:  "ap-east-1",
+						RType:   SnapshotEBS,
+					},
+				},
+			},
 			wantErr: false,
 		},
 		{
@@ -85,6 +137,494 @@ func TestApp_Run(t *testing.T) {
 	}
 }
 
+func Test_setUpRunners(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		regions    []string
+		wantPublic int
+		wantTotal  int
+	}{
+		{
+			name:       "ECR Public is scanned once in the aws partition",
+			regions:    []string{"eu-west-1", "us-east-1"},
+			wantPublic: 1,
+			wantTotal:  3,
+		},
+		{
+			name:       "ECR Public is skipped in the aws-cn partition",
+			regions:    []string{"cn-north-1", "cn-northwest-1"},
+			wantPublic: 0,
+			wantTotal:  2,
+		},
+		{
+			name:       "ECR Public is skipped in the aws-us-gov partition",
+			regions:    []string{"us-gov-west-1"},
+			wantPublic: 0,
+			wantTotal:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			runners := setUpRunners(
+				aws.Config{},
+				[]RunnerType{RepositoryECR},
+				tt.regions,
+				nil,
+				&Endpoints{},
+				newRateLimits(0),
+			)
+
+			var public int
+
+			for _, runner := range runners {
+				if _, ok := runner.(*ECRPublicRepositoryScan); ok {
+					public++
+				}
+			}
+
+			if public != tt.wantPublic || len(runners) != tt.wantTotal {
+				t.Errorf("setUpRunners() public = %d, total = %d, want %d and %d",
+					public, len(runners), tt.wantPublic, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestApp_RunLinksImageSnapshots(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	a := &App{
+		Runners: []Runner{
+			&EBSSnapshotScan{
+				baseRunner: baseRunner{
+					region:     "eu-west-1",
+					runnerType: SnapshotEBS,
+				},
+				client: &mockEBSSnapshotClient{
+					mockSnapshot: []types.Snapshot{
+						{
+							CompletionTime: &now,
+							SnapshotId:     aws.String("snap-42"),
+						},
+					},
+					mockSnapshotErr: nil,
+				},
+			},
+			&AMIScan{
+				baseRunner: baseRunner{
+					region:     "eu-west-1",
+					runnerType: ImageAMI,
+				},
+				client: &mockAMIClient{
+					mockImages: []types.Image{
+						{
+							BlockDeviceMappings: []types.BlockDeviceMapping{
+								{Ebs: &types.EbsBlockDevice{SnapshotId: aws.String("snap-42")}},
+							},
+							CreationDate: aws.String("2025-01-01T00:00:00.000Z"),
+							ImageId:      aws.String("ami-42"),
+						},
+					},
+				},
+			},
+		},
+		workerLimit: 2,
+	}
+
+	got, err := a.Run(t.Context(), "42")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	parents := make(map[string]string)
+	for _, result := range got.Results {
+		parents[result.Identifier] = result.ParentImage
+	}
+
+	want := map[string]string{"ami-42": "", "snap-42": "ami-42"}
+	if !reflect.DeepEqual(parents, want) {
+		t.Errorf("Run() parent images = %v, want %v", parents, want)
+	}
+
+	if got.Distinct() != 1 {
+		t.Errorf("Run() distinct = %d, want 1", got.Distinct())
+	}
+}
+
+func TestApp_RunMany(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	mockRunners := []Runner{
+		&EBSSnapshotScan{
+			baseRunner: baseRunner{
+				region:     "eu-west-1",
+				runnerType: SnapshotEBS,
+			},
+			client: &mockEBSSnapshotClient{
+				mockSnapshot: []types.Snapshot{
+					{
+						CompletionTime: &now,
+						SnapshotId:     aws.String("snap-42"),
+					},
+				},
+				mockSnapshotErr: nil,
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		targets []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "fail without targets",
+			targets: nil,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "fail with empty target",
+			targets: []string{"42", ""},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "results carry the target account",
+			targets: []string{"42", "self", "42"},
+			want:    []string{"1337", "42"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &App{
+				accountID:   "1337",
+				Runners:     mockRunners,
+				workerLimit: 2,
+			}
+
+			got, err := a.RunMany(t.Context(), tt.targets)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RunMany() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got == nil {
+				return
+			}
+
+			var accounts []string
+			for _, result := range got.Results {
+				accounts = append(accounts, result.Account)
+			}
+
+			sort.Strings(accounts)
+
+			if !reflect.DeepEqual(accounts, tt.want) {
+				t.Errorf("RunMany() accounts = %v, want %v", accounts, tt.want)
+			}
+		})
+	}
+}
+
+func TestApp_RunStream(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	a := &App{
+		accountID: "1337",
+		Runners: []Runner{
+			&EBSSnapshotScan{
+				baseRunner: baseRunner{
+					region:     "eu-west-1",
+					runnerType: SnapshotEBS,
+				},
+				client: &mockEBSSnapshotClient{
+					mockSnapshot: []types.Snapshot{
+						{
+							CompletionTime: &now,
+							SnapshotId:     aws.String("snap-42"),
+						},
+					},
+					mockSnapshotErr: nil,
+				},
+			},
+			&EBSSnapshotScan{
+				baseRunner: baseRunner{
+					region:     "eu-west-2",
+					runnerType: SnapshotEBS,
+				},
+				client: &mockEBSSnapshotClient{
+					mockSnapshot:    nil,
+					mockSnapshotErr: errors.New("some error"),
+				},
+			},
+			&EBSSnapshotScan{
+				baseRunner: baseRunner{
+					region:     "eu-west-3",
+					runnerType: SnapshotEBS,
+				},
+				client: &mockEBSSnapshotClient{
+					mockSnapshot:    nil,
+					mockSnapshotErr: nil,
+				},
+			},
+		},
+		workerLimit: 3,
+	}
+
+	var batches [][]Result
+
+	got, err := a.RunStream(t.Context(), []string{"42", "self"}, func(results []Result) {
+		batches = append(batches, results)
+	})
+	if err != nil {
+		t.Fatalf("RunStream() error = %v", err)
+	}
+
+	if len(got.Results) != 0 {
+		t.Errorf("RunStream() report should not hold results, got %v", got.Results)
+	}
+
+	if len(got.Failures) != 2 {
+		t.Errorf("RunStream() failures = %d, want 2", len(got.Failures))
+	}
+
+	var accounts []string
+
+	for _, batch := range batches {
+		if len(batch) != 1 {
+			t.Errorf("RunStream() batch size = %d, want 1", len(batch))
+		}
+
+		for _, result := range batch {
+			accounts = append(accounts, result.Account)
+		}
+	}
+
+	sort.Strings(accounts)
+
+	if !reflect.DeepEqual(accounts, []string{"1337", "42"}) {
+		t.Errorf("RunStream() accounts = %v, want [1337 42]", accounts)
+	}
+}
+
+func TestApp_RunManyWithAssumeRole(t *testing.T) {
+	t.Parallel()
+
+	a := &App{
+		accountID: "1337",
+		assumeRole: AssumeRole{
+			ExternalID:  "",
+			RoleARN:     "arn:aws:iam::{account}:role/SparkAudit",
+			SessionName: "spark",
+		},
+		Runners: []Runner{
+			&EBSSnapshotScan{
+				baseRunner: baseRunner{
+					region:     "eu-west-1",
+					runnerType: SnapshotEBS,
+				},
+				client: &mockEBSSnapshotClient{},
+			},
+		},
+		stsClient: &mockSTSClient{
+			mockAssumeRoleErr: &smithy.GenericAPIError{
+				Code:    "AccessDenied",
+				Message: "not authorized to perform sts:AssumeRole",
+			},
+		},
+		workerLimit: 1,
+	}
+
+	got, err := a.RunMany(t.Context(), []string{"42"})
+	if err != nil {
+		t.Fatalf("RunMany() error = %v", err)
+	}
+
+	if len(got.Results) != 0 {
+		t.Errorf("RunMany() results = %v, want none", got.Results)
+	}
+
+	if len(got.Failures) != 1 {
+		t.Fatalf("RunMany() failures = %v, want 1", got.Failures)
+	}
+
+	failure := got.Failures[0]
+	if failure.Account != "42" || failure.Class != FailureAccessDenied ||
+		failure.RType != SnapshotEBS {
+		t.Errorf("RunMany() failure = %+v", failure)
+	}
+}
+
+func TestApp_targetConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		assumeRole AssumeRole
+		stsClient  stsClient
+		wantKey    string
+		wantToken  string
+		wantErr    bool
+	}{
+		{
+			name: "fail when role cannot be assumed",
+			assumeRole: AssumeRole{
+				ExternalID:  "",
+				RoleARN:     "arn:aws:iam::{account}:role/SparkAudit",
+				SessionName: "spark",
+			},
+			stsClient: &mockSTSClient{
+				mockAssumeRoleErr: errors.New("some error"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "assume templated role with external ID",
+			assumeRole: AssumeRole{
+				ExternalID:  "secret",
+				RoleARN:     "arn:aws:iam::{account}:role/SparkAudit",
+				SessionName: "spark",
+			},
+			stsClient: &mockSTSClient{},
+			wantKey:   "arn:aws:iam::42:role/SparkAudit",
+			wantToken: "secret",
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &App{
+				assumeRole: tt.assumeRole,
+				baseCfg:    aws.Config{Region: "eu-west-1"},
+				stsClient:  tt.stsClient,
+			}
+
+			cfg, err := a.targetConfig(t.Context(), "42")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("targetConfig() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			creds, err := cfg.Credentials.Retrieve(t.Context())
+			if err != nil {
+				t.Fatalf("Retrieve() error = %v", err)
+			}
+
+			if creds.AccessKeyID != tt.wantKey {
+				t.Errorf("targetConfig() role = %v, want %v", creds.AccessKeyID, tt.wantKey)
+			}
+
+			if creds.SessionToken != tt.wantToken {
+				t.Errorf(
+					"targetConfig() external ID = %v, want %v",
+					creds.SessionToken,
+					tt.wantToken,
+				)
+			}
+
+			if cfg.Region != "eu-west-1" {
+				t.Errorf("targetConfig() region = %v, want eu-west-1", cfg.Region)
+			}
+		})
+	}
+}
+
+func TestApp_ListOrganizationAccounts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		orgClient organizationsClient
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name: "fail with error",
+			orgClient: &mockOrganizationsClient{
+				mockAccounts:        nil,
+				mockListAccountsErr: errors.New("some error"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "fail without active accounts",
+			orgClient: &mockOrganizationsClient{
+				mockAccounts: []orgTypes.Account{
+					{
+						Id:    aws.String("42"),
+						State: orgTypes.AccountStateSuspended,
+					},
+				},
+				mockListAccountsErr: nil,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "return only active accounts",
+			orgClient: &mockOrganizationsClient{
+				mockAccounts: []orgTypes.Account{
+					{
+						Id:    aws.String("42"),
+						State: orgTypes.AccountStateActive,
+					},
+					{
+						Id:    aws.String("1337"),
+						State: orgTypes.AccountStateClosed,
+					},
+				},
+				mockListAccountsErr: nil,
+			},
+			want:    []string{"42"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a := &App{
+				orgClient: tt.orgClient,
+			}
+
+			got, err := a.ListOrganizationAccounts(t.Context())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListOrganizationAccounts() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListOrganizationAccounts() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestApp_GetAccountID(t *testing.T) {
 	t.Parallel()
 
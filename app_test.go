@@ -78,6 +78,17 @@ func Test_NewApp(t *testing.T) {
 			},
 			wantApp: true,
 		},
+		{
+			name:    "scans ECR Public only once",
+			check:   []spark.RunnerType{spark.RepositoryECR},
+			regions: []string{"eu-west-1", "eu-west-2"},
+			wantWorkers: []spark.RunnerType{
+				spark.RepositoryECR,
+				spark.RepositoryECR,
+				spark.RepositoryECR,
+			},
+			wantApp: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,334 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	hubTypes "github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+)
+
+const (
+	asffSchemaVersion = "2018-10-08"
+	asffFindingType   = "Software and Configuration Checks/AWS Security Best Practices"
+	asffResourceOther = "Other"
+	// asffBatchSize is the maximum number of findings accepted by a single BatchImportFindings call.
+	asffBatchSize = 100
+)
+
+// ErrImportFindings is returned when Security Hub rejects some of the imported findings.
+var ErrImportFindings = errors.New("failed to import findings into Security Hub")
+
+var _ securityHubClient = (*securityhub.Client)(nil)
+
+type securityHubClient interface {
+	BatchImportFindings(
+		ctx context.Context,
+		params *securityhub.BatchImportFindingsInput,
+		optFns ...func(*securityhub.Options),
+	) (*securityhub.BatchImportFindingsOutput, error)
+}
+
+// securityHubClients returns a function that creates a Security Hub client for the given region.
+func securityHubClients(baseCfg aws.Config) func(region string) securityHubClient {
+	return func(region string) securityHubClient {
+		cfg := baseCfg.Copy()
+		cfg.Region = region
+
+		return securityhub.NewFromConfig(cfg)
+	}
+}
+
+// asffFinding is the subset of the AWS Security Finding Format filled in for each Result.
+type asffFinding struct {
+	AwsAccountID  string         `json:"AwsAccountId"`
+	CreatedAt     string         `json:"CreatedAt"`
+	Description   string         `json:"Description"`
+	GeneratorID   string         `json:"GeneratorId"`
+	ID            string         `json:"Id"`
+	ProductArn    string         `json:"ProductArn"`
+	Resources     []asffResource `json:"Resources"`
+	SchemaVersion string         `json:"SchemaVersion"`
+	Severity      asffSeverity   `json:"Severity"`
+	Title         string         `json:"Title"`
+	Types         []string       `json:"Types"`
+	UpdatedAt     string         `json:"UpdatedAt"`
+}
+
+type asffResource struct {
+	ID        string `json:"Id"`
+	Partition string `json:"Partition"`
+	Region    string `json:"Region"`
+	Type      string `json:"Type"`
+}
+
+type asffSeverity struct {
+	Label string `json:"Label"`
+}
+
+// formatASFF returns a JSON list of AWS Security Finding Format documents, one per Result.
+func formatASFF(report *Report, opts *outputOptions) ([]byte, error) {
+	marshal, err := json.MarshalIndent(
+		asffFindings(report, opts.productAccount, opts.now),
+		"",
+		"  ",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ASFF output, %w", err)
+	}
+
+	return marshal, nil
+}
+
+// ImportFindings sends the results of a Report to Security Hub in the region of each result.
+// Findings are imported into the account of the App, so results of other target accounts are skipped.
+func (a *App) ImportFindings(ctx context.Context, report *Report) error {
+	byRegion := make(map[string][]asffFinding)
+
+	var skipped int
+
+	for _, finding := range asffFindings(report, a.accountID, time.Now()) {
+		if finding.AwsAccountID != a.accountID {
+			skipped++
+
+			continue
+		}
+
+		region := finding.Resources[0].Region
+		byRegion[region] = append(byRegion[region], finding)
+	}
+
+	if skipped > 0 {
+		slog.Warn("skipped findings of other target accounts",
+			slog.String("account", a.accountID),
+			slog.Int("skipped", skipped),
+		)
+	}
+
+	regions := make([]string, 0, len(byRegion))
+	for region := range byRegion {
+		regions = append(regions, region)
+	}
+
+	sort.Strings(regions)
+
+	var rejected []error
+
+	for _, region := range regions {
+		client := a.securityHub(region)
+		findings := byRegion[region]
+
+		for start := 0; start < len(findings); start += asffBatchSize {
+			batch := findings[start:min(start+asffBatchSize, len(findings))]
+
+			input := make([]hubTypes.AwsSecurityFinding, 0, len(batch))
+			for _, finding := range batch {
+				input = append(input, finding.toSecurityHub())
+			}
+
+			output, err := client.BatchImportFindings(ctx, &securityhub.BatchImportFindingsInput{
+				Findings: input,
+			})
+			if err != nil {
+				return fmt.Errorf("%w in %s, %w", ErrImportFindings, region, err)
+			}
+
+			for _, failed := range output.FailedFindings {
+				rejected = append(rejected, fmt.Errorf("%s in %s, %s: %s",
+					aws.ToString(failed.Id),
+					region,
+					aws.ToString(failed.ErrorCode),
+					aws.ToString(failed.ErrorMessage),
+				))
+			}
+
+			// FailedFindings lists the rejected findings, the count covers a response without them
+			if missing := int(aws.ToInt32(output.FailedCount)) - len(output.FailedFindings); missing > 0 {
+				rejected = append(rejected, fmt.Errorf("%d finding(s) in %s", missing, region))
+			}
+		}
+	}
+
+	if len(rejected) > 0 {
+		return fmt.Errorf("%w, %w", ErrImportFindings, errors.Join(rejected...))
+	}
+
+	return nil
+}
+
+// asffFindings maps every Result of a Report to an AWS Security Finding Format document.
+// A finding is created when its result was first seen, or now when that is not known.
+func asffFindings(report *Report, productAccount string, now time.Time) []asffFinding {
+	timestamp := now.UTC().Format(time.RFC3339)
+
+	findings := make([]asffFinding, 0, len(report.Results))
+	for _, result := range report.Results {
+		partition := RegionPartition(result.Region)
+
+		createdAt := result.FirstSeen
+		if createdAt == "" {
+			createdAt = timestamp
+		}
+
+		findings = append(findings, asffFinding{
+			AwsAccountID: result.Account,
+			CreatedAt:    createdAt,
+			Description:  result.summary(),
+			GeneratorID:  toolName + "/" + result.RType.String(),
+			ID: strings.Join(
+				[]string{
+					toolName,
+					result.Account,
+					result.Region,
+					result.RType.String(),
+					result.Identifier,
+				},
+				"/",
+			),
+			ProductArn: fmt.Sprintf("arn:%s:securityhub:%s:%s:product/%s/default",
+				partition, result.Region, productAccount, productAccount),
+			Resources: []asffResource{
+				{
+					ID:        asffResourceID(&result),
+					Partition: partition,
+					Region:    result.Region,
+					Type:      asffResourceType(&result),
+				},
+			},
+			SchemaVersion: asffSchemaVersion,
+			Severity:      asffSeverity{Label: asffSeverityLabel(&result)},
+			Title:         result.RType.description(),
+			Types:         []string{asffFindingType},
+			UpdatedAt:     timestamp,
+		})
+	}
+
+	return findings
+}
+
+// toSecurityHub converts the finding to the Security Hub API type.
+func (f *asffFinding) toSecurityHub() hubTypes.AwsSecurityFinding {
+	resources := make([]hubTypes.Resource, 0, len(f.Resources))
+	for _, resource := range f.Resources {
+		resources = append(resources, hubTypes.Resource{ //nolint:exhaustruct
+			Id:        aws.String(resource.ID),
+			Partition: hubTypes.Partition(resource.Partition),
+			Region:    aws.String(resource.Region),
+			Type:      aws.String(resource.Type),
+		})
+	}
+
+	return hubTypes.AwsSecurityFinding{ //nolint:exhaustruct
+		AwsAccountId:  aws.String(f.AwsAccountID),
+		CreatedAt:     aws.String(f.CreatedAt),
+		Description:   aws.String(f.Description),
+		GeneratorId:   aws.String(f.GeneratorID),
+		Id:            aws.String(f.ID),
+		ProductArn:    aws.String(f.ProductArn),
+		Resources:     resources,
+		SchemaVersion: aws.String(f.SchemaVersion),
+		Severity: &hubTypes.Severity{ //nolint:exhaustruct
+			Label: hubTypes.SeverityLabel(f.Severity.Label),
+		},
+		Title:     aws.String(f.Title),
+		Types:     f.Types,
+		UpdatedAt: aws.String(f.UpdatedAt),
+	}
+}
+
+// asffResourceType maps the RunnerType of a result to the ASFF resource type.
+// SnapshotRDS results include Aurora and Multi-AZ cluster snapshots, told apart by their ARN.
+func asffResourceType(result *Result) string {
+	switch result.RType {
+	case ImageAMI:
+		return "AwsEc2Image"
+	case SnapshotEBS:
+		return "AwsEc2Snapshot"
+	case SnapshotRDS:
+		if strings.Contains(asffResourceID(result), ":cluster-snapshot:") {
+			return "AwsRdsDbClusterSnapshot"
+		}
+
+		return "AwsRdsDbSnapshot"
+	case DocumentSSM:
+		return "AwsSsmDocument"
+	case LayerLambda:
+		return "AwsLambdaLayerVersion"
+	case SnapshotRedshift:
+		return "AwsRedshiftClusterSnapshot"
+	case SnapshotDocDB, SnapshotNeptune:
+		return "AwsRdsDbClusterSnapshot"
+	case RepositoryECR:
+		return "AwsEcrRepository"
+	default:
+		return asffResourceOther
+	}
+}
+
+// asffResourceID returns the ARN of the resource when it can be derived from the Result, or its identifier.
+func asffResourceID(result *Result) string {
+	if strings.HasPrefix(result.Identifier, "arn:") {
+		return result.Identifier
+	}
+
+	if result.Details != nil && result.Details.ARN != "" {
+		return result.Details.ARN
+	}
+
+	partition := RegionPartition(result.Region)
+
+	switch result.RType { //nolint:exhaustive
+	case ImageAMI:
+		return fmt.Sprintf("arn:%s:ec2:%s::image/%s", partition, result.Region, result.Identifier)
+	case SnapshotEBS:
+		return fmt.Sprintf(
+			"arn:%s:ec2:%s::snapshot/%s",
+			partition,
+			result.Region,
+			result.Identifier,
+		)
+	case DocumentSSM:
+		return fmt.Sprintf("arn:%s:ssm:%s:%s:document/%s",
+			partition, result.Region, result.Account, result.Identifier)
+	default:
+		return result.Identifier
+	}
+}
+
+// asffSeverityLabel maps the severity of a result to an ASFF severity label,
+// falling back to its exposure when the result was not scored.
+func asffSeverityLabel(result *Result) string {
+	switch result.Severity {
+	case SeverityCritical:
+		return string(hubTypes.SeverityLabelCritical)
+	case SeverityHigh:
+		return string(hubTypes.SeverityLabelHigh)
+	case SeverityMedium:
+		return string(hubTypes.SeverityLabelMedium)
+	case SeverityLow:
+		return string(hubTypes.SeverityLabelLow)
+	case SeverityInfo:
+		return string(hubTypes.SeverityLabelInformational)
+	}
+
+	switch result.Exposure {
+	case ExposurePublic:
+		return string(hubTypes.SeverityLabelHigh)
+	case ExposureShared, ExposureUnknown:
+		return string(hubTypes.SeverityLabelMedium)
+	case ExposurePrivate:
+		return string(hubTypes.SeverityLabelInformational)
+	default:
+		return string(hubTypes.SeverityLabelMedium)
+	}
+}
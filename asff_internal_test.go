@@ -0,0 +1,319 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	hubTypes "github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+)
+
+type mockSecurityHubClient struct {
+	batches            [][]string
+	mockFailedCount    int32
+	mockFailedFindings []hubTypes.ImportFindingsError
+	mockImportErr      error
+}
+
+func (m *mockSecurityHubClient) BatchImportFindings(
+	_ context.Context,
+	params *securityhub.BatchImportFindingsInput,
+	_ ...func(*securityhub.Options),
+) (*securityhub.BatchImportFindingsOutput, error) {
+	ids := make([]string, 0, len(params.Findings))
+	for _, finding := range params.Findings {
+		ids = append(ids, aws.ToString(finding.Resources[0].Region))
+	}
+
+	m.batches = append(m.batches, ids)
+
+	return &securityhub.BatchImportFindingsOutput{
+		FailedCount:    aws.Int32(m.mockFailedCount),
+		FailedFindings: m.mockFailedFindings,
+		SuccessCount:   aws.Int32(int32(len(ids)) - m.mockFailedCount), //nolint:gosec
+	}, m.mockImportErr
+}
+
+var _ securityHubClient = (*mockSecurityHubClient)(nil)
+
+func Test_asffFindings(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	report := &Report{
+		Results: []Result{
+			{
+				Account:    "42",
+				Exposure:   ExposurePublic,
+				Identifier: "ami-1",
+				Region:     "eu-west-1",
+				RType:      ImageAMI,
+			},
+			{
+				Account:    "42",
+				Exposure:   ExposureShared,
+				FirstSeen:  "2024-06-01T00:00:00Z",
+				Identifier: "snap-1",
+				Region:     "eu-west-1",
+				RType:      SnapshotEBS,
+			},
+		},
+	}
+
+	want := []asffFinding{
+		{
+			AwsAccountID:  "42",
+			CreatedAt:     "2025-01-02T03:04:05Z",
+			Description:   "AMI ami-1 in account 42 (eu-west-1) is public",
+			GeneratorID:   "spark/AMI",
+			ID:            "spark/42/eu-west-1/AMI/ami-1",
+			ProductArn:    "arn:aws:securityhub:eu-west-1:1337:product/1337/default",
+			SchemaVersion: "2018-10-08",
+			Resources: []asffResource{
+				{
+					ID:        "arn:aws:ec2:eu-west-1::image/ami-1",
+					Partition: "aws",
+					Region:    "eu-west-1",
+					Type:      "AwsEc2Image",
+				},
+			},
+			Severity:  asffSeverity{Label: "HIGH"},
+			Title:     "AMI is shared outside the owner account",
+			Types:     []string{asffFindingType},
+			UpdatedAt: "2025-01-02T03:04:05Z",
+		},
+		{
+			AwsAccountID:  "42",
+			CreatedAt:     "2024-06-01T00:00:00Z",
+			Description:   "snapshotsEBS snap-1 in account 42 (eu-west-1) is shared",
+			GeneratorID:   "spark/snapshotsEBS",
+			ID:            "spark/42/eu-west-1/snapshotsEBS/snap-1",
+			ProductArn:    "arn:aws:securityhub:eu-west-1:1337:product/1337/default",
+			SchemaVersion: "2018-10-08",
+			Resources: []asffResource{
+				{
+					ID:        "arn:aws:ec2:eu-west-1::snapshot/snap-1",
+					Partition: "aws",
+					Region:    "eu-west-1",
+					Type:      "AwsEc2Snapshot",
+				},
+			},
+			Severity:  asffSeverity{Label: "MEDIUM"},
+			Title:     "EBS snapshot is shared outside the owner account",
+			Types:     []string{asffFindingType},
+			UpdatedAt: "2025-01-02T03:04:05Z",
+		},
+	}
+
+	got := asffFindings(report, "1337", now)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("asffFindings() got = %v, want %v", got, want)
+	}
+}
+
+func TestApp_ImportFindings(t *testing.T) {
+	t.Parallel()
+
+	manyResults := make([]Result, 0, asffBatchSize+1)
+	for idx := range asffBatchSize + 1 {
+		manyResults = append(manyResults, Result{
+			Account:    "42",
+			Identifier: "snap-" + strconv.Itoa(idx),
+			Region:     "eu-west-1",
+			RType:      SnapshotEBS,
+		})
+	}
+
+	tests := []struct {
+		name        string
+		client      *mockSecurityHubClient
+		results     []Result
+		wantBatches map[string][]int
+		wantErr     bool
+	}{
+		{
+			name:        "no results",
+			client:      &mockSecurityHubClient{},
+			results:     nil,
+			wantBatches: map[string][]int{},
+			wantErr:     false,
+		},
+		{
+			name:   "results are imported in batches per region",
+			client: &mockSecurityHubClient{},
+			results: append(manyResults, Result{
+				Account:    "42",
+				Identifier: "ami-1",
+				Region:     "us-east-1",
+				RType:      ImageAMI,
+			}),
+			wantBatches: map[string][]int{
+				"eu-west-1": {asffBatchSize, 1},
+				"us-east-1": {1},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "results of other accounts are skipped",
+			client: &mockSecurityHubClient{},
+			results: []Result{
+				{Account: "42", Identifier: "ami-1", Region: "eu-west-1", RType: ImageAMI},
+				{Account: "1337", Identifier: "ami-2", Region: "eu-west-1", RType: ImageAMI},
+				{Account: "1337", Identifier: "ami-3", Region: "us-east-1", RType: ImageAMI},
+			},
+			wantBatches: map[string][]int{"eu-west-1": {1}},
+			wantErr:     false,
+		},
+		{
+			name: "should fail with the rejected findings",
+			client: &mockSecurityHubClient{
+				mockFailedCount: 1,
+				mockFailedFindings: []hubTypes.ImportFindingsError{
+					{
+						ErrorCode:    aws.String("AccessDeniedException"),
+						ErrorMessage: aws.String("not authorized"),
+						Id:           aws.String("spark/42/eu-west-1/AMI/ami-1"),
+					},
+				},
+			},
+			results: []Result{
+				{Account: "42", Identifier: "ami-1", Region: "eu-west-1", RType: ImageAMI},
+			},
+			wantBatches: map[string][]int{"eu-west-1": {1}},
+			wantErr:     true,
+		},
+		{
+			name:   "should fail when findings are rejected",
+			client: &mockSecurityHubClient{mockFailedCount: 1},
+			results: []Result{
+				{Account: "42", Identifier: "ami-1", Region: "eu-west-1", RType: ImageAMI},
+			},
+			wantBatches: map[string][]int{"eu-west-1": {1}},
+			wantErr:     true,
+		},
+		{
+			name:   "should fail when api returns error",
+			client: &mockSecurityHubClient{mockImportErr: errors.New("some error")},
+			results: []Result{
+				{Account: "42", Identifier: "ami-1", Region: "eu-west-1", RType: ImageAMI},
+			},
+			wantBatches: map[string][]int{"eu-west-1": {1}},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clients := make(map[string]*mockSecurityHubClient)
+			app := &App{
+				accountID: "42",
+				securityHub: func(region string) securityHubClient {
+					client := &mockSecurityHubClient{
+						mockFailedCount:    tt.client.mockFailedCount,
+						mockFailedFindings: tt.client.mockFailedFindings,
+						mockImportErr:      tt.client.mockImportErr,
+					}
+					clients[region] = client
+
+					return client
+				},
+			}
+
+			err := app.ImportFindings(t.Context(), &Report{Results: tt.results})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ImportFindings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrImportFindings) {
+				t.Errorf("ImportFindings() error = %v, want %v", err, ErrImportFindings)
+			}
+
+			gotBatches := make(map[string][]int)
+			for region, client := range clients {
+				for _, batch := range client.batches {
+					gotBatches[region] = append(gotBatches[region], len(batch))
+				}
+			}
+
+			if !reflect.DeepEqual(gotBatches, tt.wantBatches) {
+				t.Errorf("ImportFindings() batches = %v, want %v", gotBatches, tt.wantBatches)
+			}
+		})
+	}
+}
+
+func Test_asffResourceType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		result   Result
+		wantID   string
+		wantType string
+	}{
+		{
+			name: "RDS instance snapshot",
+			result: Result{
+				Details:    &Details{ARN: "arn:aws:rds:eu-west-1:42:snapshot:db-1"},
+				Identifier: "db-1",
+				Region:     "eu-west-1",
+				RType:      SnapshotRDS,
+			},
+			wantID:   "arn:aws:rds:eu-west-1:42:snapshot:db-1",
+			wantType: "AwsRdsDbSnapshot",
+		},
+		{
+			name: "Aurora cluster snapshot",
+			result: Result{
+				Details:    &Details{ARN: "arn:aws:rds:eu-west-1:42:cluster-snapshot:aurora-1"},
+				Identifier: "aurora-1",
+				Region:     "eu-west-1",
+				RType:      SnapshotRDS,
+			},
+			wantID:   "arn:aws:rds:eu-west-1:42:cluster-snapshot:aurora-1",
+			wantType: "AwsRdsDbClusterSnapshot",
+		},
+		{
+			name: "shared cluster snapshot identified by its ARN",
+			result: Result{
+				Identifier: "arn:aws:rds:eu-west-1:1337:cluster-snapshot:aurora-2",
+				Region:     "eu-west-1",
+				RType:      SnapshotRDS,
+			},
+			wantID:   "arn:aws:rds:eu-west-1:1337:cluster-snapshot:aurora-2",
+			wantType: "AwsRdsDbClusterSnapshot",
+		},
+		{
+			name: "RDS snapshot without ARN",
+			result: Result{
+				Identifier: "db-2",
+				Region:     "eu-west-1",
+				RType:      SnapshotRDS,
+			},
+			wantID:   "db-2",
+			wantType: "AwsRdsDbSnapshot",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := asffResourceID(&tt.result); got != tt.wantID {
+				t.Errorf("asffResourceID() got = %v, want %v", got, tt.wantID)
+			}
+
+			if got := asffResourceType(&tt.result); got != tt.wantType {
+				t.Errorf("asffResourceType() got = %v, want %v", got, tt.wantType)
+			}
+		})
+	}
+}
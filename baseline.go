@@ -0,0 +1,149 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Diff classifies the results of a scan against a baseline Report.
+type Diff struct {
+	// New holds results that are not part of the baseline.
+	New []Result `json:"new"`
+	// Present holds results that are part of both the baseline and the current scan.
+	Present []Result `json:"present"`
+	// Resolved holds baseline results that are no longer found by the current scan.
+	Resolved []Result `json:"resolved"`
+}
+
+// LoadBaseline reads a Report previously written by PrepareOutput in the JSON format.
+func LoadBaseline(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline, %w", err)
+	}
+
+	var report Report
+
+	err = json.Unmarshal(data, &report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s, %w", path, err)
+	}
+
+	return &report, nil
+}
+
+// DiffReports compares the current Report with a baseline, matching results by account, region, type, and identifier.
+// Suppressed results of either report count as found, so suppressing a result does not report it as new or resolved.
+// Baseline results covered by a failed runner are not reported as resolved, since the scan could not check them.
+func DiffReports(baseline *Report, current *Report) *Diff {
+	known := make(map[string]struct{})
+	for _, result := range baseline.allResults() {
+		known[result.key()] = struct{}{}
+	}
+
+	found := make(map[string]struct{}, len(current.Results)+len(current.Suppressed))
+	for _, result := range current.Suppressed {
+		found[result.key()] = struct{}{}
+	}
+
+	diff := &Diff{
+		New:      nil,
+		Present:  nil,
+		Resolved: nil,
+	}
+
+	for _, result := range current.Results {
+		found[result.key()] = struct{}{}
+
+		if _, ok := known[result.key()]; ok {
+			diff.Present = append(diff.Present, result)
+		} else {
+			diff.New = append(diff.New, result)
+		}
+	}
+
+	failed := make(map[string]struct{}, len(current.Failures))
+	for _, failure := range current.Failures {
+		failed[failureKey(failure.Account, failure.Region, failure.RType)] = struct{}{}
+	}
+
+	for _, result := range baseline.activeResults() {
+		if _, ok := found[result.key()]; ok {
+			continue
+		}
+
+		if _, ok := failed[failureKey(result.Account, result.Region, result.RType)]; ok {
+			continue
+		}
+
+		diff.Resolved = append(diff.Resolved, result)
+	}
+
+	slices.SortStableFunc(diff.New, compareResults)
+	slices.SortStableFunc(diff.Present, compareResults)
+	slices.SortStableFunc(diff.Resolved, compareResults)
+
+	return diff
+}
+
+// MarkFirstSeen sets the time each result was first reported, results also found in the baseline keep its time.
+func (r *Report) MarkFirstSeen(baseline *Report, now time.Time) {
+	seen := make(map[string]string)
+
+	if baseline != nil {
+		for _, result := range baseline.allResults() {
+			seen[result.key()] = result.FirstSeen
+		}
+	}
+
+	timestamp := now.UTC().Format(time.RFC3339)
+
+	for idx := range r.Results {
+		result := &r.Results[idx]
+		if result.FirstSeen != "" {
+			continue
+		}
+
+		result.FirstSeen = seen[result.key()]
+		if result.FirstSeen == "" {
+			result.FirstSeen = timestamp
+		}
+	}
+}
+
+// activeResults returns the results found by the scan of a Report, in diff mode its results only hold the new ones,
+// so the results that were already present are added back.
+func (r *Report) activeResults() []Result {
+	if r.Diff == nil {
+		return r.Results
+	}
+
+	return slices.Concat(r.Results, r.Diff.Present)
+}
+
+// allResults returns the active results of a Report along with its suppressed results.
+func (r *Report) allResults() []Result {
+	results := slices.Clip(r.activeResults())
+	for _, result := range r.Suppressed {
+		results = append(results, result.Result)
+	}
+
+	return results
+}
+
+// key identifies the scanned resource across runs.
+func (r *Result) key() string {
+	return strings.Join([]string{r.Account, r.Region, r.RType.String(), r.Identifier}, "\x00")
+}
+
+// failureKey identifies the runner that scanned a resource type in a region of an account.
+func failureKey(account string, region string, rType RunnerType) string {
+	return strings.Join([]string{account, region, rType.String()}, "\x00")
+}
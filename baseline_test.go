@@ -0,0 +1,271 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wakeful/spark"
+)
+
+func Test_LoadBaseline(t *testing.T) {
+	t.Parallel()
+
+	report := &spark.Report{
+		Results: []spark.Result{
+			{
+				Account:      "42",
+				CreationDate: "2025-01-01T00:00:00Z",
+				Exposure:     spark.ExposurePublic,
+				Identifier:   "snap-1",
+				Region:       "eu-west-1",
+				RType:        spark.SnapshotEBS,
+			},
+		},
+	}
+
+	output, err := spark.PrepareOutput(report)
+	if err != nil {
+		t.Fatalf("PrepareOutput() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "baseline.json")
+	invalid := filepath.Join(dir, "invalid.json")
+
+	err = os.WriteFile(valid, output, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(invalid, []byte(`{"results":[{"type":"unknown"}]}`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    *spark.Report
+		wantErr bool
+	}{
+		{
+			name:    "round trip of the JSON output",
+			path:    valid,
+			want:    report,
+			wantErr: false,
+		},
+		{
+			name:    "should fail with unknown resource type",
+			path:    invalid,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "should fail when file is missing",
+			path:    filepath.Join(dir, "missing.json"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := spark.LoadBaseline(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadBaseline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadBaseline() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_DiffReports(t *testing.T) {
+	t.Parallel()
+
+	ami := spark.Result{
+		Account:    "42",
+		Identifier: "ami-1",
+		Region:     "eu-west-1",
+		RType:      spark.ImageAMI,
+	}
+	snapshot := spark.Result{
+		Account:    "42",
+		Identifier: "snap-1",
+		Region:     "eu-west-1",
+		RType:      spark.SnapshotEBS,
+	}
+	document := spark.Result{
+		Account:    "42",
+		Identifier: "doc-1",
+		Region:     "eu-west-1",
+		RType:      spark.DocumentSSM,
+	}
+	layer := spark.Result{
+		Account:    "42",
+		Identifier: "layer-1",
+		Region:     "us-east-1",
+		RType:      spark.LayerLambda,
+	}
+	otherRegion := spark.Result{
+		Account:    "42",
+		Identifier: "ami-1",
+		Region:     "eu-west-2",
+		RType:      spark.ImageAMI,
+	}
+
+	tests := []struct {
+		name     string
+		baseline *spark.Report
+		current  *spark.Report
+		want     *spark.Diff
+	}{
+		{
+			name: "results are new, present, or resolved",
+			baseline: &spark.Report{
+				Results: []spark.Result{ami, snapshot, layer},
+			},
+			current: &spark.Report{
+				Results: []spark.Result{otherRegion, document, ami},
+				Failures: []spark.Failure{
+					{Account: "42", Region: "us-east-1", RType: spark.LayerLambda},
+				},
+			},
+			want: &spark.Diff{
+				New:      []spark.Result{document, otherRegion},
+				Present:  []spark.Result{ami},
+				Resolved: []spark.Result{snapshot},
+			},
+		},
+		{
+			name: "suppressed results are neither new nor resolved",
+			baseline: &spark.Report{
+				Results:    []spark.Result{ami, snapshot},
+				Suppressed: []spark.SuppressedResult{{Result: document, Reason: "approved"}},
+			},
+			current: &spark.Report{
+				Results:    []spark.Result{document},
+				Suppressed: []spark.SuppressedResult{{Result: ami, Reason: "approved"}},
+			},
+			want: &spark.Diff{
+				New:      nil,
+				Present:  []spark.Result{document},
+				Resolved: []spark.Result{snapshot},
+			},
+		},
+		{
+			name: "present results of a baseline written in diff mode are known",
+			baseline: &spark.Report{
+				Results: []spark.Result{document},
+				Diff: &spark.Diff{
+					New:      []spark.Result{document},
+					Present:  []spark.Result{ami},
+					Resolved: []spark.Result{snapshot},
+				},
+			},
+			current: &spark.Report{
+				Results: []spark.Result{ami, snapshot},
+			},
+			want: &spark.Diff{
+				New:      []spark.Result{snapshot},
+				Present:  []spark.Result{ami},
+				Resolved: []spark.Result{document},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := spark.DiffReports(tt.baseline, tt.current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffReports() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_MarkFirstSeen(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	nowTime := "2025-01-02T03:04:05Z"
+	ami := spark.Result{
+		Account:    "42",
+		Identifier: "ami-1",
+		Region:     "eu-west-1",
+		RType:      spark.ImageAMI,
+	}
+	snapshot := spark.Result{
+		Account:    "42",
+		Identifier: "snap-1",
+		Region:     "eu-west-1",
+		RType:      spark.SnapshotEBS,
+	}
+	document := spark.Result{
+		Account:    "42",
+		Identifier: "doc-1",
+		Region:     "eu-west-1",
+		RType:      spark.DocumentSSM,
+	}
+
+	seenAMI := ami
+	seenAMI.FirstSeen = "2024-06-01T00:00:00Z"
+	seenSnapshot := snapshot
+	seenSnapshot.FirstSeen = "2024-07-01T00:00:00Z"
+
+	tests := []struct {
+		name     string
+		baseline *spark.Report
+		want     []string
+	}{
+		{
+			name:     "without baseline every result is first seen now",
+			baseline: nil,
+			want:     []string{nowTime, nowTime, nowTime},
+		},
+		{
+			name: "results and suppressed results of the baseline keep their time",
+			baseline: &spark.Report{
+				Results:    []spark.Result{seenAMI},
+				Suppressed: []spark.SuppressedResult{{Result: seenSnapshot}},
+			},
+			want: []string{"2024-06-01T00:00:00Z", "2024-07-01T00:00:00Z", nowTime},
+		},
+		{
+			name: "baseline results without a time are first seen now",
+			baseline: &spark.Report{
+				Results: []spark.Result{ami},
+			},
+			want: []string{nowTime, nowTime, nowTime},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := &spark.Report{
+				Results: []spark.Result{ami, snapshot, document},
+			}
+			report.MarkFirstSeen(tt.baseline, now)
+
+			got := make([]string, 0, len(report.Results))
+			for _, result := range report.Results {
+				got = append(got, result.FirstSeen)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MarkFirstSeen() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
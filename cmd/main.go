@@ -10,6 +10,8 @@ import (
 	"flag"
 	"log/slog"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/wakeful/spark"
@@ -17,19 +19,153 @@ import (
 
 var version = "dev"
 
-func main() { //nolint:cyclop
+// Process exit codes, so pipelines can gate on the outcome of a scan.
+const (
+	// exitOK means the scan completed without findings.
+	exitOK = 0
+	// exitFindings means the scan found results matching -fail-on.
+	exitFindings = 1
+	// exitScanFailure means some runners failed, or the scan could not complete.
+	exitScanFailure = 2
+	// exitConfigError means the flags, input files, or AWS configuration are invalid.
+	exitConfigError = 3
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run executes the command and returns the process exit code.
+func run() int { //nolint:cyclop,funlen,gocognit
 	const numberOfWorkers = 2
 
 	var (
-		target         = flag.String("target", "self", "target AWS account ID")
 		listScanners   = flag.Bool("list-scanners", false, "list available resource types")
 		showVersion    = flag.Bool("version", false, "show version")
 		verbose        = flag.Bool("verbose", false, "verbose log output")
-		scanAllRegions = flag.Bool("region-all", false, "scan all regions")
+		scanAllRegions = flag.Bool("region-all", false, "scan all regions enabled for the account")
 		scannersAll    = flag.Bool("scan-all", false, "scan all resource types")
-		workerCount    = flag.Int("workers", numberOfWorkers, "number of workers used for scanning")
-		regionVars     spark.StringSlice
-		scannersVars   spark.StringSlice
+		baselinePath   = flag.String(
+			"baseline",
+			"",
+			"previous JSON output, only results missing from it are reported",
+		)
+		outputFormat = flag.String(
+			"output",
+			spark.OutputJSON,
+			"output format: "+strings.Join(spark.OutputFormats(), ", "),
+		)
+		partition = flag.String(
+			"partition",
+			spark.PartitionAWS,
+			"AWS partition to scan: "+strings.Join(spark.Partitions(), ", "),
+		)
+		scoringPath = flag.String(
+			"severity-rules",
+			"",
+			"YAML or JSON file overriding the default severity scoring rules",
+		)
+		ssmSecrets = flag.Bool(
+			"ssm-secrets",
+			false,
+			"fetch the content of shared SSM documents and search it for hardcoded secrets",
+		)
+		suppressionsPath = flag.String(
+			"suppressions",
+			"",
+			"YAML or JSON file listing resources that are shared on purpose",
+		)
+		importFindings = flag.Bool(
+			"securityhub-import",
+			false,
+			"import results into AWS Security Hub via BatchImportFindings",
+		)
+		failOnValue = flag.String(
+			"fail-on",
+			spark.FailOnAny,
+			"results that fail the run: "+strings.Join(
+				[]string{
+					spark.FailOnAny,
+					spark.FailOnNew,
+					spark.FailOnNone,
+					spark.FailOnSeverity + "high",
+				},
+				", ",
+			),
+		)
+		targetOrg = flag.Bool(
+			"target-org",
+			false,
+			"scan all active accounts in the AWS Organization",
+		)
+		endpointURL = flag.String(
+			"endpoint-url",
+			"",
+			"send every AWS API call to this endpoint, e.g. a local emulator",
+		)
+		profile = flag.String(
+			"profile",
+			"",
+			"AWS shared config profile to use",
+		)
+		sharedConfigFile = flag.String(
+			"shared-config-file",
+			"",
+			"AWS shared config file to use instead of ~/.aws/config",
+		)
+		credentialsFile = flag.String(
+			"credentials-file",
+			"",
+			"AWS shared credentials file to use instead of ~/.aws/credentials",
+		)
+		webIdentityTokenFile = flag.String(
+			"web-identity-token-file",
+			"",
+			"OIDC token file exchanged for credentials of -web-identity-role-arn",
+		)
+		webIdentityRoleARN = flag.String(
+			"web-identity-role-arn",
+			"",
+			"role ARN assumed with -web-identity-token-file",
+		)
+		retryMode = flag.String(
+			"retry-mode",
+			"",
+			"retry mode of AWS API calls: "+strings.Join(spark.RetryModes(), ", ")+
+				", the AWS config or standard is used when empty",
+		)
+		maxAttempts = flag.Int(
+			"max-attempts",
+			0,
+			"maximum attempts of each AWS API call, 0 uses the SDK default",
+		)
+		rateLimit = flag.Float64(
+			"rate-limit",
+			0,
+			"maximum AWS API calls per second to each service in each region, 0 disables the limit",
+		)
+		workerCount = flag.Int("workers", numberOfWorkers, "number of workers used for scanning")
+		roleARN     = flag.String(
+			"role-arn",
+			"",
+			"role ARN to assume in each target account, {account} is replaced with the account ID",
+		)
+		externalID  = flag.String("external-id", "", "external ID used when assuming -role-arn")
+		sessionName = flag.String(
+			"session-name",
+			"spark",
+			"session name used when assuming -role-arn",
+		)
+		endpointVars spark.StringSlice
+		regionVars   spark.StringSlice
+		scannersVars spark.StringSlice
+		targetVars   spark.StringSlice
+	)
+
+	flag.Var(
+		&endpointVars,
+		"service-endpoint",
+		"service=url endpoint override of a single service (can be specified multiple times)",
 	)
 
 	flag.Var(
@@ -42,6 +178,11 @@ func main() { //nolint:cyclop
 		"scan",
 		"AWS resource type to scan (can be specified multiple times)",
 	)
+	flag.Var(
+		&targetVars,
+		"target",
+		"target AWS account ID or self (can be specified multiple times, default self)",
+	)
 	flag.Parse()
 
 	slog.SetDefault(spark.GetLogger(os.Stderr, verbose))
@@ -53,7 +194,51 @@ func main() { //nolint:cyclop
 			slog.String("version", version),
 		)
 
-		return
+		return exitOK
+	}
+
+	format := strings.ToLower(*outputFormat)
+	if !slices.Contains(spark.OutputFormats(), format) {
+		slog.Error("unsupported output format", slog.String("output", *outputFormat))
+
+		return exitConfigError
+	}
+
+	partitionRegions, err := spark.PartitionRegions(*partition)
+	if err != nil {
+		slog.Error("invalid partition", slog.String("error", err.Error()))
+
+		return exitConfigError
+	}
+
+	for _, region := range regionVars {
+		if spark.RegionPartition(region) != *partition {
+			slog.Error("region is not part of the partition",
+				slog.String("partition", *partition),
+				slog.String("region", region),
+			)
+
+			return exitConfigError
+		}
+	}
+
+	if *retryMode != "" && !slices.Contains(spark.RetryModes(), *retryMode) {
+		slog.Error("unsupported retry mode", slog.String("retry-mode", *retryMode))
+
+		return exitConfigError
+	}
+
+	failOn, err := spark.ParseFailOn(*failOnValue)
+	if err != nil {
+		slog.Error("invalid fail-on value", slog.String("error", err.Error()))
+
+		return exitConfigError
+	}
+
+	if failOn.RequiresBaseline() && *baselinePath == "" {
+		slog.Error("-fail-on new requires -baseline")
+
+		return exitConfigError
 	}
 
 	types := spark.GetSupportedScanners()
@@ -65,13 +250,18 @@ func main() { //nolint:cyclop
 			_, _ = os.Stdout.Write([]byte(rType + "\n"))
 		}
 
-		return
+		return exitOK
 	}
 
+	// the default region of the profile is only resolved by NewApp, so it checks the partition too
+	options := []spark.Option{spark.WithPartition(*partition)}
+
 	if *scanAllRegions {
-		slog.Debug("scan all regions")
+		slog.Debug("scan all enabled regions")
 
-		regionVars = spark.SupportedRegions
+		regionVars = partitionRegions
+
+		options = append(options, spark.WithRegionDiscovery())
 	}
 
 	if *scannersAll {
@@ -89,16 +279,83 @@ func main() { //nolint:cyclop
 		go spark.Spinner(ctx, os.Stderr, ticker.C)
 	}
 
+	if *scoringPath != "" {
+		scoring, errScoring := spark.LoadScoringRules(*scoringPath)
+		if errScoring != nil {
+			slog.Error("failed to load severity rules", slog.String("error", errScoring.Error()))
+
+			return exitConfigError
+		}
+
+		options = append(options, spark.WithScoring(scoring))
+	}
+
+	if *endpointURL != "" || len(endpointVars) > 0 {
+		if *endpointURL != "" {
+			errEndpoint := spark.ValidateEndpointURL(*endpointURL)
+			if errEndpoint != nil {
+				slog.Error("invalid endpoint URL", slog.String("error", errEndpoint.Error()))
+
+				return exitConfigError
+			}
+		}
+
+		services, errEndpoints := spark.ParseServiceEndpoints(endpointVars)
+		if errEndpoints != nil {
+			slog.Error("invalid service endpoint",
+				slog.String("error", errEndpoints.Error()),
+				slog.String("services", strings.Join(spark.EndpointServices(), ",")),
+			)
+
+			return exitConfigError
+		}
+
+		options = append(options, spark.WithEndpoints(spark.Endpoints{
+			URL:      *endpointURL,
+			Services: services,
+		}))
+	}
+
+	options = append(options, spark.WithCredentialSource(spark.CredentialSource{
+		Profile:              *profile,
+		ConfigFile:           *sharedConfigFile,
+		CredentialsFile:      *credentialsFile,
+		WebIdentityTokenFile: *webIdentityTokenFile,
+		WebIdentityRoleARN:   *webIdentityRoleARN,
+	}))
+
+	options = append(
+		options,
+		spark.WithRetryPolicy(spark.RetryPolicy{
+			Mode:        *retryMode,
+			MaxAttempts: *maxAttempts,
+		}),
+		spark.WithRateLimit(*rateLimit),
+	)
+
+	if *ssmSecrets {
+		options = append(options, spark.WithSecretDetectors(spark.DefaultSecretDetectors()))
+	}
+
+	if *roleARN != "" {
+		options = append(options, spark.WithAssumeRole(spark.AssumeRole{
+			ExternalID:  *externalID,
+			RoleARN:     *roleARN,
+			SessionName: *sessionName,
+		}))
+	}
+
 	app, err := spark.NewApp(
 		ctx,
 		spark.GetRunners(scannersVars),
 		regionVars,
 		*workerCount,
+		options...,
 	)
 	if err != nil {
 		slog.Error("failed to initialize app", slog.String("error", err.Error()))
 
-		return
+		return exitConfigError
 	}
 
 	errGetAccountID := app.GetAccountID(ctx)
@@ -108,22 +365,165 @@ func main() { //nolint:cyclop
 			slog.String("error", errGetAccountID.Error()),
 		)
 
-		return
+		return exitConfigError
+	}
+
+	if *targetOrg {
+		accounts, errListAccounts := app.ListOrganizationAccounts(ctx)
+		if errListAccounts != nil {
+			slog.Error(
+				"failed to list organization accounts",
+				slog.String("error", errListAccounts.Error()),
+			)
+
+			return exitConfigError
+		}
+
+		slog.Debug("scan organization accounts", slog.Int("count", len(accounts)))
+
+		targetVars = append(targetVars, accounts...)
+	}
+
+	if len(targetVars) == 0 {
+		targetVars = spark.StringSlice{"self"}
+	}
+
+	var baseline *spark.Report
+
+	if *baselinePath != "" {
+		baseline, err = spark.LoadBaseline(*baselinePath)
+		if err != nil {
+			slog.Error("failed to load baseline", slog.String("error", err.Error()))
+
+			return exitConfigError
+		}
+	}
+
+	var suppressions *spark.Suppressions
+
+	if *suppressionsPath != "" {
+		suppressions, err = spark.LoadSuppressions(*suppressionsPath)
+		if err != nil {
+			slog.Error("failed to load suppressions", slog.String("error", err.Error()))
+
+			return exitConfigError
+		}
+	}
+
+	// results are filtered by the baseline and suppressions after the scan, so they cannot be streamed
+	streaming := format == spark.OutputNDJSON && baseline == nil && suppressions == nil
+	if format == spark.OutputNDJSON && !streaming {
+		slog.Warn("ndjson output is buffered until the scan finishes",
+			slog.String("reason", "-baseline or -suppressions is set"),
+		)
+	}
+
+	var (
+		report   *spark.Report
+		streamed []spark.Result
+	)
+
+	if streaming {
+		report, err = app.RunStream(ctx, targetVars, func(results []spark.Result) {
+			streamed = append(streamed, results...)
+
+			errWrite := spark.WriteNDJSON(os.Stdout, results)
+			if errWrite != nil {
+				slog.Error("failed to write results", slog.String("error", errWrite.Error()))
+			}
+		})
+	} else {
+		report, err = app.RunMany(ctx, targetVars)
 	}
 
-	output, err := app.Run(ctx, *target)
 	if err != nil {
 		slog.Error("failed to run checks", slog.String("error", err.Error()))
 
-		return
+		return exitScanFailure
 	}
 
-	marshal, err := spark.PrepareOutput(output)
-	if err != nil {
-		slog.Error("failed to marshal output", slog.String("error", err.Error()))
+	if streaming {
+		report.Results = streamed
+		report.LinkImageSnapshots()
+	}
+
+	report.MarkFirstSeen(baseline, time.Now())
+
+	throttles := app.Throttles()
+
+	var throttled int
+	for _, count := range throttles {
+		throttled += count
+	}
+
+	slog.Info("scan finished",
+		slog.Int("results", len(report.Results)),
+		slog.Int("distinct", report.Distinct()),
+		slog.Int("throttled", throttled),
+	)
+
+	if throttled > 0 {
+		slog.Warn("AWS API calls were throttled, consider fewer -workers or a -rate-limit",
+			slog.Any("throttles", throttles),
+		)
+	}
+
+	if len(report.Failures) > 0 {
+		slog.Warn("scan finished with partial results", slog.Int("failures", len(report.Failures)))
+	}
+
+	if suppressions != nil {
+		suppressions.Apply(report, time.Now())
+
+		slog.Info("applied suppressions", slog.Int("suppressed", len(report.Suppressed)))
+	}
+
+	if baseline != nil {
+		diff := spark.DiffReports(baseline, report)
+
+		slog.Info("compared with baseline",
+			slog.Int("new", len(diff.New)),
+			slog.Int("present", len(diff.Present)),
+			slog.Int("resolved", len(diff.Resolved)),
+		)
+
+		report.Results = diff.New
+		report.Diff = diff
+	}
+
+	scanFailed := len(report.Failures) > 0
+
+	if *importFindings {
+		errImport := app.ImportFindings(ctx, report)
+		if errImport != nil {
+			slog.Error("failed to import findings", slog.String("error", errImport.Error()))
 
-		return
+			scanFailed = true
+		}
+	}
+
+	if !streaming {
+		marshal, errOutput := spark.PrepareOutput(
+			report,
+			spark.WithOutputFormat(format),
+			spark.WithProductAccount(app.AccountID()),
+			spark.WithToolVersion(version),
+		)
+		if errOutput != nil {
+			slog.Error("failed to marshal output", slog.String("error", errOutput.Error()))
+
+			return exitScanFailure
+		}
+
+		_, _ = os.Stdout.Write(marshal)
 	}
 
-	_, _ = os.Stdout.Write(marshal)
+	switch {
+	case failOn.Failed(report):
+		return exitFindings
+	case scanFailed:
+		return exitScanFailure
+	default:
+		return exitOK
+	}
 }
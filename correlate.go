@@ -0,0 +1,69 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import "strings"
+
+// LinkImageSnapshots sets the ParentImage of EBS snapshot results that back an AMI found in the same account and
+// region, so a leaked image and its snapshots can be reported as a single exposure. RunMany links its results,
+// results collected from RunStream should be linked once the scan finished.
+func (r *Report) LinkImageSnapshots() {
+	parents := make(map[string]string)
+
+	for _, result := range r.Results {
+		if result.RType != ImageAMI {
+			continue
+		}
+
+		for _, snapshotID := range result.BackingSnapshots {
+			parents[snapshotKey(result.Account, result.Region, snapshotID)] = result.Identifier
+		}
+	}
+
+	if len(parents) == 0 {
+		return
+	}
+
+	for idx := range r.Results {
+		result := &r.Results[idx]
+		if result.RType != SnapshotEBS {
+			continue
+		}
+
+		parent, ok := parents[snapshotKey(result.Account, result.Region, result.Identifier)]
+		if ok {
+			result.ParentImage = parent
+		}
+	}
+}
+
+// snapshotKey identifies an EBS snapshot or an AMI of an account in a region.
+func snapshotKey(account string, region string, snapshotID string) string {
+	return strings.Join([]string{account, region, snapshotID}, "\x00")
+}
+
+// Distinct returns the number of results, not counting EBS snapshots whose parent AMI is part of the report.
+func (r *Report) Distinct() int {
+	images := make(map[string]struct{})
+
+	for _, result := range r.Results {
+		if result.RType == ImageAMI {
+			images[snapshotKey(result.Account, result.Region, result.Identifier)] = struct{}{}
+		}
+	}
+
+	count := 0
+
+	for _, result := range r.Results {
+		if result.ParentImage != "" {
+			if _, ok := images[snapshotKey(result.Account, result.Region, result.ParentImage)]; ok {
+				continue
+			}
+		}
+
+		count++
+	}
+
+	return count
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReport_LinkImageSnapshots(t *testing.T) {
+	t.Parallel()
+
+	image := Result{
+		Account:          "42",
+		BackingSnapshots: []string{"snap-1", "snap-2"},
+		Identifier:       "ami-1",
+		Region:           "eu-west-1",
+		RType:            ImageAMI,
+	}
+	backing := Result{Account: "42", Identifier: "snap-1", Region: "eu-west-1", RType: SnapshotEBS}
+	unrelated := Result{
+		Account:    "42",
+		Identifier: "snap-3",
+		Region:     "eu-west-1",
+		RType:      SnapshotEBS,
+	}
+	otherRegion := Result{
+		Account:    "42",
+		Identifier: "snap-2",
+		Region:     "eu-west-2",
+		RType:      SnapshotEBS,
+	}
+	otherAccount := Result{
+		Account:    "1337",
+		Identifier: "snap-2",
+		Region:     "eu-west-1",
+		RType:      SnapshotEBS,
+	}
+
+	linked := backing
+	linked.ParentImage = "ami-1"
+
+	results := []Result{backing, unrelated, otherRegion, image, otherAccount}
+	want := []Result{linked, unrelated, otherRegion, image, otherAccount}
+
+	(&Report{Results: results}).LinkImageSnapshots()
+
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("LinkImageSnapshots() got = %v, want %v", results, want)
+	}
+
+	tests := []struct {
+		name   string
+		report *Report
+		want   int
+	}{
+		{
+			name:   "snapshot linked to a reported AMI",
+			report: &Report{Results: results},
+			want:   4,
+		},
+		{
+			name:   "snapshot linked to an AMI missing from the report",
+			report: &Report{Results: []Result{linked, unrelated}},
+			want:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.report.Distinct(); got != tt.want {
+				t.Errorf("Distinct() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
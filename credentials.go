@@ -0,0 +1,68 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// CredentialSource selects where the AWS configuration and credentials of the App are loaded from.
+// Empty fields keep the default credential chain of the AWS SDK.
+type CredentialSource struct {
+	// Profile is the name of the shared config profile to use.
+	Profile string
+	// ConfigFile replaces the default shared config file, ~/.aws/config.
+	ConfigFile string
+	// CredentialsFile replaces the default shared credentials file, ~/.aws/credentials.
+	CredentialsFile string
+	// WebIdentityTokenFile is an OIDC token file exchanged for credentials of WebIdentityRoleARN.
+	WebIdentityTokenFile string
+	// WebIdentityRoleARN is the role assumed with the web identity token.
+	WebIdentityRoleARN string
+}
+
+// validate checks that a web identity token is always paired with the role it is exchanged for.
+func (c *CredentialSource) validate() error {
+	if (c.WebIdentityTokenFile == "") != (c.WebIdentityRoleARN == "") {
+		return ErrWebIdentity
+	}
+
+	return nil
+}
+
+// loadOptions returns the options passed to config.LoadDefaultConfig.
+func (c *CredentialSource) loadOptions() []func(*config.LoadOptions) error {
+	var output []func(*config.LoadOptions) error
+
+	if c.Profile != "" {
+		output = append(output, config.WithSharedConfigProfile(c.Profile))
+	}
+
+	if c.ConfigFile != "" {
+		output = append(output, config.WithSharedConfigFiles([]string{c.ConfigFile}))
+	}
+
+	if c.CredentialsFile != "" {
+		output = append(output, config.WithSharedCredentialsFiles([]string{c.CredentialsFile}))
+	}
+
+	return output
+}
+
+// webIdentityCredentials returns credentials of the web identity role, obtained with the STS client config.
+func (c *CredentialSource) webIdentityCredentials(stsCfg aws.Config) aws.CredentialsProvider {
+	return aws.NewCredentialsCache(
+		stscreds.NewWebIdentityRoleProvider(
+			sts.NewFromConfig(stsCfg),
+			c.WebIdentityRoleARN,
+			stscreds.IdentityTokenFile(c.WebIdentityTokenFile),
+			func(o *stscreds.WebIdentityRoleOptions) {
+				o.RoleSessionName = defaultSessionName
+			},
+		),
+	)
+}
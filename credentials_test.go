@@ -0,0 +1,193 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/wakeful/spark"
+)
+
+//nolint:paralleltest // the AWS SDK is configured with t.Setenv
+func Test_AppWithCredentialSource(t *testing.T) {
+	dir := t.TempDir()
+
+	configFile := filepath.Join(dir, "audit-config")
+	credentialsFile := filepath.Join(dir, "audit-credentials")
+	tokenFile := filepath.Join(dir, "token")
+
+	for filePath, content := range map[string]string{
+		configFile:      "[profile audit]\nregion = eu-central-1\n",
+		credentialsFile: "[audit]\naws_access_key_id = AKIAPROFILEEXAMPLE\naws_secret_access_key = fake\n",
+		tokenFile:       "eyJhbGciOiJSUzI1NiJ9.fake.token",
+	} {
+		err := os.WriteFile(filePath, []byte(content), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		source        spark.CredentialSource
+		regions       []string
+		partition     string
+		wantErr       bool
+		errIs         error
+		wantAccessKey string
+		wantRegion    string
+	}{
+		{
+			name: "profile from custom files sets the credentials and default region",
+			source: spark.CredentialSource{
+				Profile:              "audit",
+				ConfigFile:           configFile,
+				CredentialsFile:      credentialsFile,
+				WebIdentityTokenFile: "",
+				WebIdentityRoleARN:   "",
+			},
+			regions:       nil,
+			partition:     spark.PartitionAWS,
+			wantErr:       false,
+			errIs:         nil,
+			wantAccessKey: "AKIAPROFILEEXAMPLE",
+			wantRegion:    "eu-central-1",
+		},
+		{
+			name: "regions take precedence over the profile region",
+			source: spark.CredentialSource{
+				Profile:              "audit",
+				ConfigFile:           configFile,
+				CredentialsFile:      credentialsFile,
+				WebIdentityTokenFile: "",
+				WebIdentityRoleARN:   "",
+			},
+			regions:       []string{"eu-west-2"},
+			partition:     spark.PartitionAWS,
+			wantErr:       false,
+			errIs:         nil,
+			wantAccessKey: "AKIAPROFILEEXAMPLE",
+			wantRegion:    "eu-west-2",
+		},
+		{
+			name: "web identity token is exchanged for role credentials",
+			source: spark.CredentialSource{
+				Profile:              "",
+				ConfigFile:           "",
+				CredentialsFile:      "",
+				WebIdentityTokenFile: tokenFile,
+				WebIdentityRoleARN:   "arn:aws:iam::123456789012:role/ci",
+			},
+			regions:       []string{"eu-west-1"},
+			partition:     spark.PartitionAWS,
+			wantErr:       false,
+			errIs:         nil,
+			wantAccessKey: "ASIAWEBIDENTITYEXAMPLE",
+			wantRegion:    "eu-west-1",
+		},
+		{
+			name: "should fail with web identity token without role",
+			source: spark.CredentialSource{
+				Profile:              "",
+				ConfigFile:           "",
+				CredentialsFile:      "",
+				WebIdentityTokenFile: tokenFile,
+				WebIdentityRoleARN:   "",
+			},
+			regions:       []string{"eu-west-1"},
+			partition:     spark.PartitionAWS,
+			wantErr:       true,
+			errIs:         spark.ErrWebIdentity,
+			wantAccessKey: "",
+			wantRegion:    "",
+		},
+		{
+			name: "should fail when the profile region is not in the partition",
+			source: spark.CredentialSource{
+				Profile:              "audit",
+				ConfigFile:           configFile,
+				CredentialsFile:      credentialsFile,
+				WebIdentityTokenFile: "",
+				WebIdentityRoleARN:   "",
+			},
+			regions:       nil,
+			partition:     spark.PartitionChina,
+			wantErr:       true,
+			errIs:         spark.ErrRegionPartition,
+			wantAccessKey: "",
+			wantRegion:    "",
+		},
+		{
+			name: "should fail with unknown profile",
+			source: spark.CredentialSource{
+				Profile:              "missing",
+				ConfigFile:           configFile,
+				CredentialsFile:      credentialsFile,
+				WebIdentityTokenFile: "",
+				WebIdentityRoleARN:   "",
+			},
+			regions:       []string{"eu-west-1"},
+			partition:     spark.PartitionAWS,
+			wantErr:       true,
+			errIs:         nil,
+			wantAccessKey: "",
+			wantRegion:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setFakeCredentials(t)
+			// AWS_REGION takes precedence over the region of the profile
+			t.Setenv("AWS_REGION", "")
+
+			fake, server := newFakeAWS(t)
+
+			app, err := spark.NewApp(
+				t.Context(),
+				[]spark.RunnerType{spark.ImageAMI},
+				tt.regions,
+				1,
+				spark.WithCredentialSource(tt.source),
+				spark.WithPartition(tt.partition),
+				spark.WithEndpoints(spark.Endpoints{
+					URL:      server.URL,
+					Services: nil,
+				}),
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewApp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("NewApp() error = %v, want %v", err, tt.errIs)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			err = app.GetAccountID(t.Context())
+			if err != nil {
+				t.Fatalf("GetAccountID() error = %v", err)
+			}
+
+			idx := slices.Index(fake.calls, "GetCallerIdentity")
+			if idx < 0 {
+				t.Fatalf("GetCallerIdentity was not sent to the endpoint, calls = %v", fake.calls)
+			}
+
+			// STS is called in the first region, so its scope shows both the credentials and the region
+			scope := strings.Split(fake.scopes[idx], "/")
+			if len(scope) < 3 || scope[0] != tt.wantAccessKey || scope[2] != tt.wantRegion {
+				t.Errorf("GetCallerIdentity scope = %s, want %s in %s",
+					fake.scopes[idx], tt.wantAccessKey, tt.wantRegion)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// Names of the services whose endpoint can be overridden.
+const (
+	serviceEC2           = "ec2"
+	serviceECR           = "ecr"
+	serviceECRPublic     = "ecrpublic"
+	serviceLambda        = "lambda"
+	serviceOrganizations = "organizations"
+	serviceRDS           = "rds"
+	serviceRedshift      = "redshift"
+	serviceSecurityHub   = "securityhub"
+	serviceSSM           = "ssm"
+	serviceSTS           = "sts"
+)
+
+// EndpointServices returns the names of the services whose endpoint can be overridden.
+func EndpointServices() []string {
+	return []string{
+		serviceEC2,
+		serviceECR,
+		serviceECRPublic,
+		serviceLambda,
+		serviceOrganizations,
+		serviceRDS,
+		serviceRedshift,
+		serviceSecurityHub,
+		serviceSSM,
+		serviceSTS,
+	}
+}
+
+// Endpoints redirects AWS API calls to other endpoints, e.g. a local emulator.
+type Endpoints struct {
+	// URL is used by every service without an override in Services.
+	URL string
+	// Services maps a service name, as listed by EndpointServices, to its endpoint URL.
+	Services map[string]string
+}
+
+// ParseServiceEndpoints parses service=url pairs into a map of service endpoints.
+func ParseServiceEndpoints(values []string) (map[string]string, error) {
+	output := make(map[string]string, len(values))
+
+	for _, value := range values {
+		service, endpoint, ok := strings.Cut(value, "=")
+		service = strings.ToLower(strings.TrimSpace(service))
+
+		if !ok || !slices.Contains(EndpointServices(), service) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidEndpoint, value)
+		}
+
+		if ValidateEndpointURL(endpoint) != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidEndpoint, value)
+		}
+
+		output[service] = endpoint
+	}
+
+	return output, nil
+}
+
+// ValidateEndpointURL returns an error unless the endpoint is an absolute URL with a scheme and a host.
+func ValidateEndpointURL(endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("%w: %s", ErrInvalidEndpointURL, endpoint)
+	}
+
+	return nil
+}
+
+// config returns a copy of cfg that sends the requests of the service to its endpoint, if one is set.
+func (e *Endpoints) config(cfg aws.Config, service string) aws.Config {
+	output := cfg.Copy()
+
+	if endpoint, ok := e.Services[service]; ok {
+		output.BaseEndpoint = aws.String(endpoint)
+	} else if e.URL != "" {
+		output.BaseEndpoint = aws.String(e.URL)
+	}
+
+	return output
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/wakeful/spark"
+)
+
+func Test_ParseServiceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "no overrides",
+			values:  nil,
+			want:    map[string]string{},
+			wantErr: false,
+		},
+		{
+			name:   "service names ignore case",
+			values: []string{"EC2=http://localhost:4566", "sts=https://sts.example.com"},
+			want: map[string]string{
+				"ec2": "http://localhost:4566",
+				"sts": "https://sts.example.com",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "should fail without url",
+			values:  []string{"ec2"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "should fail with unknown service",
+			values:  []string{"s3=http://localhost:4566"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "should fail with relative url",
+			values:  []string{"rds=localhost:4566"},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := spark.ParseServiceEndpoints(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseServiceEndpoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, spark.ErrInvalidEndpoint) {
+				t.Errorf(
+					"ParseServiceEndpoints() error = %v, want %v",
+					err,
+					spark.ErrInvalidEndpoint,
+				)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseServiceEndpoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ValidateEndpointURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		wantErr  bool
+	}{
+		{
+			name:     "absolute url",
+			endpoint: "http://localhost:4566",
+			wantErr:  false,
+		},
+		{
+			name:     "should fail without scheme",
+			endpoint: "localhost:4566",
+			wantErr:  true,
+		},
+		{
+			name:     "should fail without host",
+			endpoint: "http://",
+			wantErr:  true,
+		},
+		{
+			name:     "should fail with malformed url",
+			endpoint: "http://local host:4566",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := spark.ValidateEndpointURL(tt.endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateEndpointURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, spark.ErrInvalidEndpointURL) {
+				t.Errorf(
+					"ValidateEndpointURL() error = %v, want %v",
+					err,
+					spark.ErrInvalidEndpointURL,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"strings"
+)
+
+// Exposure describes who can access a resource reported by a scan.
+type Exposure string
+
+const (
+	// ExposurePublic indicates the resource is available to every AWS account.
+	ExposurePublic Exposure = "public"
+	// ExposureShared indicates the resource is available to a list of specific accounts.
+	ExposureShared Exposure = "shared"
+	// ExposurePrivate indicates the resource is only available to its owner.
+	ExposurePrivate Exposure = "private"
+	// ExposureUnknown indicates the permissions of the resource could not be read.
+	ExposureUnknown Exposure = "unknown"
+)
+
+// permissionAll is the value used by AWS sharing APIs to grant access to every account.
+const permissionAll = "all"
+
+type callerKey struct{}
+
+// withCaller returns a context that carries the account ID the scan is performed from.
+func withCaller(ctx context.Context, account string) context.Context {
+	return context.WithValue(ctx, callerKey{}, account)
+}
+
+// callerAccount returns the account ID the scan is performed from, or an empty string when unknown.
+func callerAccount(ctx context.Context) string {
+	account, _ := ctx.Value(callerKey{}).(string)
+
+	return account
+}
+
+// isExternalScan reports whether the target is scanned from another account.
+// In that case permission APIs are not available, and every visible resource
+// that is not public has been shared with the caller.
+func isExternalScan(ctx context.Context, target string) bool {
+	caller := callerAccount(ctx)
+
+	return caller != "" && caller != target
+}
+
+// externalExposure returns the exposure of a non-public resource seen from another account.
+func externalExposure(ctx context.Context) (Exposure, []string) {
+	return ExposureShared, []string{callerAccount(ctx)}
+}
+
+// exposureFromPermissions classifies the accounts a resource is shared with, where "all" means public.
+func exposureFromPermissions(permissions []string) (Exposure, []string) {
+	var (
+		public     bool
+		sharedWith []string
+	)
+
+	for _, permission := range permissions {
+		if strings.EqualFold(permission, permissionAll) {
+			public = true
+
+			continue
+		}
+
+		sharedWith = append(sharedWith, permission)
+	}
+
+	if len(sharedWith) > 0 {
+		sharedWith = uniqStrings(sharedWith)
+	}
+
+	switch {
+	case public:
+		return ExposurePublic, sharedWith
+	case len(sharedWith) > 0:
+		return ExposureShared, sharedWith
+	default:
+		return ExposurePrivate, nil
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_exposureFromPermissions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		permissions    []string
+		wantExposure   Exposure
+		wantSharedWith []string
+	}{
+		{
+			name:           "no permissions",
+			permissions:    nil,
+			wantExposure:   ExposurePrivate,
+			wantSharedWith: nil,
+		},
+		{
+			name:           "public",
+			permissions:    []string{"all"},
+			wantExposure:   ExposurePublic,
+			wantSharedWith: nil,
+		},
+		{
+			name:           "public and shared with accounts",
+			permissions:    []string{"1337", "All", "42"},
+			wantExposure:   ExposurePublic,
+			wantSharedWith: []string{"1337", "42"},
+		},
+		{
+			name:           "shared with accounts",
+			permissions:    []string{"42", "1337", "42"},
+			wantExposure:   ExposureShared,
+			wantSharedWith: []string{"1337", "42"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotExposure, gotSharedWith := exposureFromPermissions(tt.permissions)
+			if gotExposure != tt.wantExposure {
+				t.Errorf(
+					"exposureFromPermissions() exposure = %v, want %v",
+					gotExposure,
+					tt.wantExposure,
+				)
+			}
+
+			if !reflect.DeepEqual(gotSharedWith, tt.wantSharedWith) {
+				t.Errorf(
+					"exposureFromPermissions() sharedWith = %v, want %v",
+					gotSharedWith,
+					tt.wantSharedWith,
+				)
+			}
+		})
+	}
+}
+
+func Test_isExternalScan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		caller string
+		target string
+		want   bool
+	}{
+		{
+			name:   "unknown caller",
+			caller: "",
+			target: "42",
+			want:   false,
+		},
+		{
+			name:   "same account",
+			caller: "42",
+			target: "42",
+			want:   false,
+		},
+		{
+			name:   "another account",
+			caller: "1337",
+			target: "42",
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isExternalScan(withCaller(t.Context(), tt.caller), tt.target); got != tt.want {
+				t.Errorf("isExternalScan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
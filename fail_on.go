@@ -0,0 +1,70 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// FailOnAny fails the run when the report has any result.
+	FailOnAny = "any"
+	// FailOnNew fails the run when the report has results missing from the baseline.
+	FailOnNew = "new"
+	// FailOnNone never fails the run because of results, only scan failures are reported.
+	FailOnNone = "none"
+	// FailOnSeverity is the prefix of a threshold, e.g. severity>=high fails the run on high and critical results.
+	FailOnSeverity = "severity>="
+)
+
+// FailOn decides which results of a scan should fail the run.
+type FailOn struct {
+	mode     string
+	severity Severity
+}
+
+// ParseFailOn parses a -fail-on value, one of FailOnAny, FailOnNew, FailOnNone, or a FailOnSeverity threshold.
+func ParseFailOn(value string) (FailOn, error) {
+	mode := strings.ToLower(strings.ReplaceAll(value, " ", ""))
+
+	switch mode {
+	case FailOnAny, FailOnNew, FailOnNone:
+		return FailOn{mode: mode, severity: ""}, nil
+	}
+
+	threshold, ok := strings.CutPrefix(mode, FailOnSeverity)
+	if !ok {
+		return FailOn{mode: "", severity: ""}, fmt.Errorf("%w: %s", ErrUnknownFailOn, value)
+	}
+
+	severity, err := ParseSeverity(threshold)
+	if err != nil {
+		return FailOn{mode: "", severity: ""}, fmt.Errorf("%w: %w", ErrUnknownFailOn, err)
+	}
+
+	return FailOn{mode: FailOnSeverity, severity: severity}, nil
+}
+
+// RequiresBaseline reports whether the policy can only be evaluated against a baseline.
+func (f FailOn) RequiresBaseline() bool {
+	return f.mode == FailOnNew
+}
+
+// Failed reports whether the results of the report fail the run, private results never do.
+// With FailOnNew the report is expected to hold only new results, as returned by DiffReports.
+func (f FailOn) Failed(report *Report) bool {
+	if f.mode == FailOnNone {
+		return false
+	}
+
+	return slices.ContainsFunc(report.Results, func(result Result) bool {
+		if result.Exposure == ExposurePrivate {
+			return false
+		}
+
+		return f.mode != FailOnSeverity || result.Severity.rank() >= f.severity.rank()
+	})
+}
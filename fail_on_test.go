@@ -0,0 +1,130 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wakeful/spark"
+)
+
+func Test_FailOn(t *testing.T) {
+	t.Parallel()
+
+	withResults := &spark.Report{
+		Results: []spark.Result{
+			{
+				Identifier: "snap-1",
+				Region:     "eu-west-1",
+				RType:      spark.SnapshotEBS,
+				Severity:   spark.SeverityHigh,
+			},
+		},
+	}
+	privateOnly := &spark.Report{
+		Results: []spark.Result{
+			{
+				Exposure:   spark.ExposurePrivate,
+				Identifier: "snap-2",
+				Region:     "eu-west-1",
+				RType:      spark.SnapshotEBS,
+				Severity:   spark.SeverityCritical,
+			},
+		},
+	}
+	empty := &spark.Report{}
+
+	tests := []struct {
+		name             string
+		value            string
+		wantErr          error
+		requiresBaseline bool
+		failedResults    bool
+	}{
+		{
+			name:             "any",
+			value:            "any",
+			wantErr:          nil,
+			requiresBaseline: false,
+			failedResults:    true,
+		},
+		{
+			name:             "new is case insensitive",
+			value:            "NEW",
+			wantErr:          nil,
+			requiresBaseline: true,
+			failedResults:    true,
+		},
+		{
+			name:             "none",
+			value:            "none",
+			wantErr:          nil,
+			requiresBaseline: false,
+			failedResults:    false,
+		},
+		{
+			name:             "severity at the threshold",
+			value:            "severity>=high",
+			wantErr:          nil,
+			requiresBaseline: false,
+			failedResults:    true,
+		},
+		{
+			name:             "severity below the threshold",
+			value:            "severity >= critical",
+			wantErr:          nil,
+			requiresBaseline: false,
+			failedResults:    false,
+		},
+		{
+			name:             "should fail with unknown severity",
+			value:            "severity>=urgent",
+			wantErr:          spark.ErrUnknownFailOn,
+			requiresBaseline: false,
+			failedResults:    false,
+		},
+		{
+			name:             "should fail with unknown value",
+			value:            "always",
+			wantErr:          spark.ErrUnknownFailOn,
+			requiresBaseline: false,
+			failedResults:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := spark.ParseFailOn(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseFailOn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.RequiresBaseline() != tt.requiresBaseline {
+				t.Errorf(
+					"RequiresBaseline() = %v, want %v",
+					got.RequiresBaseline(),
+					tt.requiresBaseline,
+				)
+			}
+
+			if got.Failed(withResults) != tt.failedResults {
+				t.Errorf("Failed() = %v, want %v", got.Failed(withResults), tt.failedResults)
+			}
+
+			if got.Failed(privateOnly) {
+				t.Error("Failed() = true for a report with only private results")
+			}
+
+			if got.Failed(empty) {
+				t.Error("Failed() = true for a report without results")
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/aws/smithy-go"
+)
+
+// FailureClass groups runner errors into broad categories, so reports can explain what was not covered.
+type FailureClass string
+
+const (
+	// FailureAccessDenied indicates the caller lacks permission for the scanned API.
+	FailureAccessDenied FailureClass = "accessDenied"
+	// FailureRegionDisabled indicates the region is not enabled (opted in) for the caller, including
+	// AuthFailure errors of opt-in regions.
+	FailureRegionDisabled FailureClass = "regionDisabled"
+	// FailureCredentials indicates the credentials were rejected, e.g. because they are invalid or expired.
+	// EC2 also rejects valid credentials in opt-in regions that are not enabled, see FailureRegionDisabled.
+	FailureCredentials FailureClass = "credentials"
+	// FailureThrottled indicates the API rejected the request due to rate limiting.
+	FailureThrottled FailureClass = "throttled"
+	// FailureCanceled indicates the scan was stopped before it could finish.
+	FailureCanceled FailureClass = "canceled"
+	// FailureUnknown is used for errors that do not fit into any other class.
+	FailureUnknown FailureClass = "unknown"
+)
+
+// Failure describes a single runner that could not complete its scan.
+type Failure struct {
+	Account string       `json:"account"`
+	Class   FailureClass `json:"class"`
+	Code    string       `json:"code,omitempty"`
+	Error   string       `json:"error"`
+	Region  string       `json:"region"`
+	RType   RunnerType   `json:"type"`
+}
+
+// Report represents the outcome of a scan, including partial results when some runners failed.
+type Report struct {
+	Results    []Result           `json:"results"`
+	Failures   []Failure          `json:"errors,omitempty"`
+	Suppressed []SuppressedResult `json:"suppressed,omitempty"`
+	// Diff classifies the results against a baseline, it is only set in diff mode.
+	Diff *Diff `json:"diff,omitempty"`
+}
+
+// newFailure builds a Failure for the given runner, target account, and error.
+func newFailure(runner Runner, target string, err error) Failure {
+	class, code := classifyRegionError(err, runner.getRegion())
+
+	return Failure{
+		Account: target,
+		Class:   class,
+		Code:    code,
+		Error:   err.Error(),
+		Region:  runner.getRegion(),
+		RType:   runner.RunType(),
+	}
+}
+
+// classifyRegionError classifies the error like classifyError, except that EC2 rejects the credentials
+// with AuthFailure in opt-in regions that are not enabled, so there it indicates a disabled region.
+func classifyRegionError(err error, region string) (FailureClass, string) {
+	class, code := classifyError(err)
+	if code == "AuthFailure" && slices.Contains(optInRegions, region) {
+		return FailureRegionDisabled, code
+	}
+
+	return class, code
+}
+
+// classifyError maps an error returned by a runner to a FailureClass and the AWS API error code, if any.
+func classifyError(err error) (FailureClass, string) {
+	if errors.Is(err, ErrCtxCancelled) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return FailureCanceled, ""
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return FailureUnknown, ""
+	}
+
+	code := apiErr.ErrorCode()
+
+	switch code {
+	case "AccessDenied",
+		"AccessDeniedException",
+		"AuthorizationError",
+		"UnauthorizedOperation":
+		return FailureAccessDenied, code
+	case "OptInRequired":
+		return FailureRegionDisabled, code
+	case "AuthFailure",
+		"ExpiredToken",
+		"ExpiredTokenException",
+		"InvalidClientTokenId",
+		"SignatureDoesNotMatch",
+		"UnrecognizedClientException":
+		return FailureCredentials, code
+	case "RequestLimitExceeded",
+		"Throttling",
+		"ThrottlingException",
+		"TooManyRequestsException":
+		return FailureThrottled, code
+	default:
+		return FailureUnknown, code
+	}
+}
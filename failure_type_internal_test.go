@@ -0,0 +1,160 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func Test_classifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		err       error
+		wantClass FailureClass
+		wantCode  string
+	}{
+		{
+			name:      "plain error",
+			err:       errors.New("some error"),
+			wantClass: FailureUnknown,
+			wantCode:  "",
+		},
+		{
+			name:      "cancelled scan",
+			err:       fmt.Errorf("%w: %w", ErrCtxCancelled, context.Canceled),
+			wantClass: FailureCanceled,
+			wantCode:  "",
+		},
+		{
+			name: "access denied",
+			err: fmt.Errorf("failed to fetch AMI(s), %w", &smithy.GenericAPIError{
+				Code: "UnauthorizedOperation",
+			}),
+			wantClass: FailureAccessDenied,
+			wantCode:  "UnauthorizedOperation",
+		},
+		{
+			name: "region not enabled",
+			err: &smithy.GenericAPIError{
+				Code: "OptInRequired",
+			},
+			wantClass: FailureRegionDisabled,
+			wantCode:  "OptInRequired",
+		},
+		{
+			name: "invalid or expired credentials",
+			err: &smithy.GenericAPIError{
+				Code: "AuthFailure",
+			},
+			wantClass: FailureCredentials,
+			wantCode:  "AuthFailure",
+		},
+		{
+			name: "expired session token",
+			err: &smithy.GenericAPIError{
+				Code: "ExpiredTokenException",
+			},
+			wantClass: FailureCredentials,
+			wantCode:  "ExpiredTokenException",
+		},
+		{
+			name: "throttled",
+			err: &smithy.GenericAPIError{
+				Code: "ThrottlingException",
+			},
+			wantClass: FailureThrottled,
+			wantCode:  "ThrottlingException",
+		},
+		{
+			name: "unknown api error",
+			err: &smithy.GenericAPIError{
+				Code: "InternalError",
+			},
+			wantClass: FailureUnknown,
+			wantCode:  "InternalError",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotClass, gotCode := classifyError(tt.err)
+			if gotClass != tt.wantClass {
+				t.Errorf("classifyError() class = %v, want %v", gotClass, tt.wantClass)
+			}
+
+			if gotCode != tt.wantCode {
+				t.Errorf("classifyError() code = %v, want %v", gotCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_classifyRegionError(t *testing.T) {
+	t.Parallel()
+
+	// EC2 rejects the credentials this way in opt-in regions that are not enabled
+	authFailure := fmt.Errorf("failed to fetch AMI(s), %w", &smithy.OperationError{
+		ServiceID:     "EC2",
+		OperationName: "DescribeImages",
+		Err: &smithy.GenericAPIError{
+			Code:    "AuthFailure",
+			Message: "AWS was not able to validate the provided access credentials",
+			Fault:   smithy.FaultClient,
+		},
+	})
+
+	tests := []struct {
+		name      string
+		err       error
+		region    string
+		wantClass FailureClass
+		wantCode  string
+	}{
+		{
+			name:      "auth failure in an opt-in region",
+			err:       authFailure,
+			region:    "ap-east-1",
+			wantClass: FailureRegionDisabled,
+			wantCode:  "AuthFailure",
+		},
+		{
+			name:      "auth failure in a default region",
+			err:       authFailure,
+			region:    "eu-west-1",
+			wantClass: FailureCredentials,
+			wantCode:  "AuthFailure",
+		},
+		{
+			name: "expired token in an opt-in region",
+			err: &smithy.GenericAPIError{
+				Code: "ExpiredTokenException",
+			},
+			region:    "ap-east-1",
+			wantClass: FailureCredentials,
+			wantCode:  "ExpiredTokenException",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotClass, gotCode := classifyRegionError(tt.err, tt.region)
+			if gotClass != tt.wantClass {
+				t.Errorf("classifyRegionError() class = %v, want %v", gotClass, tt.wantClass)
+			}
+
+			if gotCode != tt.wantCode {
+				t.Errorf("classifyRegionError() code = %v, want %v", gotCode, tt.wantCode)
+			}
+		})
+	}
+}
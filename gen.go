@@ -6,3 +6,5 @@
 package spark
 
 //go:generate go run ./gen/regions/main.go
+//go:generate go run ./gen/regions/main.go -partition aws-us-gov
+//go:generate go run ./gen/regions/main.go -partition aws-cn
@@ -7,6 +7,7 @@ package main
 import (
 	"context"
 	_ "embed"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
@@ -22,20 +23,62 @@ import (
 var templateContent string
 
 type regionTemplate struct {
-	Regions []string
+	Partition string
+	Regions   []string
+	VarName   string
 }
 
-const useRegion = "eu-west-1"
+// partitionTarget describes where the regions of a partition are listed from and written to.
+type partitionTarget struct {
+	fileName  string
+	useRegion string
+	varName   string
+}
+
+// partitionTargets returns the generator settings of every supported AWS partition.
+func partitionTargets() map[string]partitionTarget {
+	return map[string]partitionTarget{
+		"aws": {
+			fileName:  "./gen_regions.go",
+			useRegion: "eu-west-1",
+			varName:   "SupportedRegions",
+		},
+		"aws-us-gov": {
+			fileName:  "./gen_regions_aws_us_gov.go",
+			useRegion: "us-gov-west-1",
+			varName:   "SupportedGovCloudRegions",
+		},
+		"aws-cn": {
+			fileName:  "./gen_regions_aws_cn.go",
+			useRegion: "cn-north-1",
+			varName:   "SupportedChinaRegions",
+		},
+	}
+}
 
 func main() {
+	partition := flag.String(
+		"partition",
+		"aws",
+		"AWS partition to list the regions of: aws, aws-us-gov, aws-cn",
+	)
+	flag.Parse()
+
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		AddSource: false,
 		Level:     slog.LevelDebug,
 	})))
 
+	target, ok := partitionTargets()[*partition]
+	if !ok {
+		slog.Error("unsupported partition", slog.String("partition", *partition))
+
+		return
+	}
+
 	ctx := context.Background()
 
-	cnf, errGetConfig := config.LoadDefaultConfig(ctx, config.WithRegion(useRegion))
+	cnf, errGetConfig := config.LoadDefaultConfig(ctx, config.WithRegion(target.useRegion))
 	if errGetConfig != nil {
 		slog.Error("failed to get AWS config", slog.String("error", errGetConfig.Error()))
 
@@ -49,25 +92,33 @@ func main() {
 		return
 	}
 
-	slog.Info("found regions", slog.Int("count", len(regions)))
+	slog.Info(
+		"found regions",
+		slog.Int("count", len(regions)),
+		slog.String("partition", *partition),
+	)
 
-	target, errCreateFile := os.Create("./gen_regions.go")
+	output, errCreateFile := os.Create(target.fileName)
 	if errCreateFile != nil {
 		slog.Error("failed to create target file", slog.String("error", errCreateFile.Error()))
 
 		return
 	}
 
-	defer func(target *os.File) {
-		errCloseFile := target.Close()
+	defer func(output *os.File) {
+		errCloseFile := output.Close()
 		if errCloseFile != nil {
 			slog.Error("failed to close target file", slog.String("error", errCloseFile.Error()))
 		}
-	}(target)
+	}(output)
 
 	supportedRegionsTemplate := template.Must(template.New("").Parse(templateContent))
 
-	errTemplate := supportedRegionsTemplate.Execute(target, regionTemplate{Regions: regions})
+	errTemplate := supportedRegionsTemplate.Execute(output, regionTemplate{
+		Partition: *partition,
+		Regions:   regions,
+		VarName:   target.varName,
+	})
 	if errTemplate != nil {
 		slog.Error("failed to execute template", slog.String("error", errTemplate.Error()))
 
@@ -4,7 +4,7 @@
 // Package spark Code generated. DO NOT EDIT.
 package spark
 
-// SupportedRegions specifies the AWS regions supported for scanning by the Spark application.
+// SupportedRegions specifies the AWS regions of the aws partition supported for scanning by Spark.
 var SupportedRegions = []string{
 	"ap-northeast-1",
 	"ap-northeast-2",
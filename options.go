@@ -0,0 +1,104 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+// AccountPlaceholder is replaced with the target account ID in the AssumeRole RoleARN template.
+const AccountPlaceholder = "{account}"
+
+// defaultSessionName is used when no AssumeRole SessionName is provided.
+const defaultSessionName = "spark"
+
+// AssumeRole configures the role assumed in each target account before scanning it.
+type AssumeRole struct {
+	// ExternalID is passed to sts:AssumeRole when not empty.
+	ExternalID string
+	// RoleARN is a role ARN template, e.g. arn:aws:iam::{account}:role/SparkAudit.
+	RoleARN string
+	// SessionName is the role session name, it defaults to "spark".
+	SessionName string
+}
+
+type options struct {
+	assumeRole      AssumeRole
+	credentials     CredentialSource
+	detectors       []SecretDetector
+	discoverRegions bool
+	endpoints       Endpoints
+	partition       string
+	rateLimit       float64
+	retry           RetryPolicy
+	scoring         *ScoringRules
+}
+
+// Option configures optional App settings.
+type Option func(*options)
+
+// WithAssumeRole makes the App scan each target account using credentials from the given role.
+func WithAssumeRole(role AssumeRole) Option {
+	return func(o *options) {
+		if role.SessionName == "" {
+			role.SessionName = defaultSessionName
+		}
+
+		o.assumeRole = role
+	}
+}
+
+// WithScoring replaces the DefaultScoringRules used to set the Severity of each result.
+func WithScoring(rules *ScoringRules) Option {
+	return func(o *options) {
+		o.scoring = rules
+	}
+}
+
+// WithSecretDetectors makes the App fetch the content of shared SSM documents and search it with the detectors.
+func WithSecretDetectors(detectors []SecretDetector) Option {
+	return func(o *options) {
+		o.detectors = detectors
+	}
+}
+
+// WithRegionDiscovery makes the App scan the regions enabled for the caller, as listed by ec2:DescribeRegions,
+// instead of the given regions. The given regions are used when the enabled regions cannot be listed.
+func WithRegionDiscovery() Option {
+	return func(o *options) {
+		o.discoverRegions = true
+	}
+}
+
+// WithPartition makes NewApp fail when the regions to scan, including the default region
+// of the profile or environment, are not part of the given AWS partition.
+func WithPartition(partition string) Option {
+	return func(o *options) {
+		o.partition = partition
+	}
+}
+
+// WithEndpoints sends the AWS API calls of the App to the given endpoints, e.g. a local emulator.
+func WithEndpoints(endpoints Endpoints) Option {
+	return func(o *options) {
+		o.endpoints = endpoints
+	}
+}
+
+// WithCredentialSource loads the AWS configuration and credentials of the App from the given source.
+func WithCredentialSource(source CredentialSource) Option {
+	return func(o *options) {
+		o.credentials = source
+	}
+}
+
+// WithRetryPolicy sets the retry mode and maximum number of attempts of every AWS API call.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithRateLimit limits the API calls of the runners to requestsPerSecond for each service in each region.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(o *options) {
+		o.rateLimit = requestsPerSecond
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// OutputJSON is the default output format, an indented JSON Report.
+	OutputJSON = "json"
+	// OutputSARIF is the SARIF 2.1.0 output format.
+	OutputSARIF = "sarif"
+	// OutputASFF is the AWS Security Finding Format output, used by AWS Security Hub.
+	OutputASFF = "asff"
+	// OutputCSV is a CSV document with one row per result.
+	OutputCSV = "csv"
+	// OutputMarkdown is a Markdown table with one row per result.
+	OutputMarkdown = "markdown"
+	// OutputTable is an aligned, human-readable table grouped by region and resource type.
+	OutputTable = "table"
+	// OutputNDJSON is newline-delimited JSON with one result per line, suitable for streaming.
+	OutputNDJSON = "ndjson"
+)
+
+// formatter renders a sorted scan Report in a specific output format.
+type formatter func(report *Report, opts *outputOptions) ([]byte, error)
+
+type outputOptions struct {
+	format         string
+	now            time.Time
+	productAccount string
+	toolVersion    string
+}
+
+// OutputOption configures PrepareOutput.
+type OutputOption func(*outputOptions)
+
+// WithOutputFormat selects one of the OutputFormats, it defaults to OutputJSON.
+func WithOutputFormat(format string) OutputOption {
+	return func(o *outputOptions) {
+		o.format = format
+	}
+}
+
+// WithToolVersion sets the spark version reported by formats that describe the tool, like SARIF.
+func WithToolVersion(version string) OutputOption {
+	return func(o *outputOptions) {
+		o.toolVersion = version
+	}
+}
+
+// WithProductAccount sets the account that owns the Security Hub product used by the ASFF output.
+func WithProductAccount(account string) OutputOption {
+	return func(o *outputOptions) {
+		o.productAccount = account
+	}
+}
+
+// OutputFormats returns the supported output format names.
+func OutputFormats() []string {
+	return []string{
+		OutputJSON,
+		OutputSARIF,
+		OutputASFF,
+		OutputCSV,
+		OutputMarkdown,
+		OutputTable,
+		OutputNDJSON,
+	}
+}
+
+// PrepareOutput renders a scan Report in the selected output format, pretty-printed JSON by default.
+// Results and failures are sorted by region, type, account, and identifier, so the output is deterministic.
+func PrepareOutput(report *Report, optFns ...OutputOption) ([]byte, error) {
+	opts := outputOptions{
+		format:         OutputJSON,
+		now:            time.Now(),
+		productAccount: "",
+		toolVersion:    "",
+	}
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	format, err := getFormatter(opts.format)
+	if err != nil {
+		return nil, err
+	}
+
+	return format(sortedReport(report), &opts)
+}
+
+// getFormatter returns the formatter registered for the given output format name.
+func getFormatter(name string) (formatter, error) {
+	switch strings.ToLower(name) {
+	case OutputJSON:
+		return formatJSON, nil
+	case OutputSARIF:
+		return formatSARIF, nil
+	case OutputASFF:
+		return formatASFF, nil
+	case OutputCSV:
+		return formatCSV, nil
+	case OutputMarkdown:
+		return formatMarkdown, nil
+	case OutputTable:
+		return formatTable, nil
+	case OutputNDJSON:
+		return formatNDJSON, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOutput, name)
+	}
+}
+
+// formatJSON returns a pretty-printed JSON byte slice from a scan Report.
+func formatJSON(report *Report, _ *outputOptions) ([]byte, error) {
+	marshal, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output, %w", err)
+	}
+
+	return marshal, nil
+}
+
+// formatNDJSON returns the results of a scan Report as newline-delimited JSON.
+func formatNDJSON(report *Report, _ *outputOptions) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := WriteNDJSON(&buf, report.Results)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteNDJSON writes each result as a single line of JSON, so results can be streamed as they arrive.
+func WriteNDJSON(output io.Writer, results []Result) error {
+	encoder := json.NewEncoder(output)
+
+	for _, result := range results {
+		err := encoder.Encode(result)
+		if err != nil {
+			return fmt.Errorf("failed to write result, %w", err)
+		}
+	}
+
+	return nil
+}
+
+// compareResults orders results by region, type, account, and identifier.
+func compareResults(a, b Result) int {
+	return cmp.Or(
+		cmp.Compare(a.Region, b.Region),
+		cmp.Compare(a.RType.String(), b.RType.String()),
+		cmp.Compare(a.Account, b.Account),
+		cmp.Compare(a.Identifier, b.Identifier),
+	)
+}
+
+// sortedReport returns a copy of the report with results and failures in a stable order.
+func sortedReport(report *Report) *Report {
+	results := slices.Clone(report.Results)
+	slices.SortStableFunc(results, compareResults)
+
+	failures := slices.Clone(report.Failures)
+	slices.SortStableFunc(failures, func(a, b Failure) int {
+		return cmp.Or(
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.RType.String(), b.RType.String()),
+			cmp.Compare(a.Account, b.Account),
+			cmp.Compare(a.Error, b.Error),
+		)
+	})
+
+	suppressed := slices.Clone(report.Suppressed)
+	slices.SortStableFunc(suppressed, func(a, b SuppressedResult) int {
+		return compareResults(a.Result, b.Result)
+	})
+
+	return &Report{
+		Results:    results,
+		Failures:   failures,
+		Suppressed: suppressed,
+		Diff:       report.Diff,
+	}
+}
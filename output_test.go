@@ -0,0 +1,132 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/wakeful/spark"
+)
+
+func Test_PrepareOutputFormats(t *testing.T) {
+	t.Parallel()
+
+	report := &spark.Report{
+		Results: []spark.Result{
+			{
+				Account:      "42",
+				CreationDate: "2025-01-02T00:00:00Z",
+				Exposure:     spark.ExposureShared,
+				Identifier:   "snap-b",
+				Region:       "eu-west-1",
+				RType:        spark.SnapshotEBS,
+				Severity:     spark.SeverityMedium,
+				SharedWith:   []string{"1337", "7331"},
+			},
+			{
+				Account:      "42",
+				CreationDate: "2025-01-01T00:00:00Z",
+				Details:      &spark.Details{Encrypted: aws.Bool(true), Name: "a|b", SizeGiB: 8},
+				Exposure:     spark.ExposurePublic,
+				Identifier:   "ami-1",
+				Region:       "eu-west-1",
+				RType:        spark.ImageAMI,
+				Severity:     spark.SeverityHigh,
+			},
+			{
+				Account:      "42",
+				CreationDate: "2025-01-03T00:00:00Z",
+				Exposure:     spark.ExposurePublic,
+				Identifier:   "snap-a",
+				ParentImage:  "ami-1",
+				Region:       "eu-west-1",
+				RType:        spark.SnapshotEBS,
+				Severity:     spark.SeverityHigh,
+			},
+		},
+		Failures: []spark.Failure{
+			{
+				Account: "42",
+				Class:   spark.FailureAccessDenied,
+				Error:   "denied",
+				Region:  "us-east-1",
+				RType:   spark.SnapshotRDS,
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "csv",
+			format: spark.OutputCSV,
+			want: `account,region,type,identifier,exposure,severity,sharedWith,creationDate,engine,engineVersion,name,encrypted,kmsKeyId,sizeGiB,parentImage
+42,eu-west-1,AMI,ami-1,public,high,,2025-01-01T00:00:00Z,,,a|b,true,,8,
+42,eu-west-1,snapshotsEBS,snap-a,public,high,,2025-01-03T00:00:00Z,,,,,,,ami-1
+42,eu-west-1,snapshotsEBS,snap-b,shared,medium,1337;7331,2025-01-02T00:00:00Z,,,,,,,
+`,
+		},
+		{
+			name:   "markdown",
+			format: spark.OutputMarkdown,
+			want: `| Account | Region | Type | Identifier | Exposure | Severity | Shared with | Created |
+| --- | --- | --- | --- | --- | --- | --- | --- |
+| 42 | eu-west-1 | AMI | ami-1 | public | high |  | 2025-01-01T00:00:00Z |
+| 42 | eu-west-1 | snapshotsEBS | snap-a | public | high |  | 2025-01-03T00:00:00Z |
+| 42 | eu-west-1 | snapshotsEBS | snap-b | shared | medium | 1337, 7331 | 2025-01-02T00:00:00Z |
+
+### Errors
+
+| Account | Region | Type | Class | Error |
+| --- | --- | --- | --- | --- |
+| 42 | us-east-1 | snapshotsRDS | accessDenied | denied |
+`,
+		},
+		{
+			name:   "table",
+			format: spark.OutputTable,
+			want: `eu-west-1 / AMI (1)
+ACCOUNT  IDENTIFIER  EXPOSURE  SEVERITY  SHARED WITH  CREATED
+42       ami-1       public    high                   2025-01-01T00:00:00Z
+
+eu-west-1 / snapshotsEBS (2)
+ACCOUNT  IDENTIFIER  EXPOSURE  SEVERITY  SHARED WITH  CREATED
+42       snap-a      public    high                   2025-01-03T00:00:00Z
+42       snap-b      shared    medium    1337,7331    2025-01-02T00:00:00Z
+
+errors (1)
+ACCOUNT  REGION     TYPE          CLASS         ERROR
+42       us-east-1  snapshotsRDS  accessDenied  denied
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := spark.PrepareOutput(report, spark.WithOutputFormat(tt.format))
+			if err != nil {
+				t.Fatalf("PrepareOutput() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("PrepareOutput() got =\n%s\nwant =\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_PrepareOutputUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := spark.PrepareOutput(&spark.Report{}, spark.WithOutputFormat("xml"))
+	if !errors.Is(err, spark.ErrUnknownOutput) {
+		t.Errorf("PrepareOutput() error = %v, want %v", err, spark.ErrUnknownOutput)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// csvHeader lists the columns of the CSV output.
+func csvHeader() []string {
+	return []string{
+		"account",
+		"region",
+		"type",
+		"identifier",
+		"exposure",
+		"severity",
+		"sharedWith",
+		"creationDate",
+		"engine",
+		"engineVersion",
+		"name",
+		"encrypted",
+		"kmsKeyId",
+		"sizeGiB",
+		"parentImage",
+	}
+}
+
+// formatCSV returns a CSV document with a header and one row per result.
+func formatCSV(report *Report, _ *outputOptions) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+
+	err := writer.Write(csvHeader())
+	if err != nil {
+		return nil, fmt.Errorf("failed to write CSV header, %w", err)
+	}
+
+	for _, result := range report.Results {
+		details := result.Details
+		if details == nil {
+			details = &Details{} //nolint:exhaustruct
+		}
+
+		var encrypted, size string
+		if details.Encrypted != nil {
+			encrypted = strconv.FormatBool(*details.Encrypted)
+		}
+
+		if details.SizeGiB > 0 {
+			size = strconv.FormatInt(details.SizeGiB, 10)
+		}
+
+		err = writer.Write([]string{
+			result.Account,
+			result.Region,
+			result.RType.String(),
+			result.Identifier,
+			string(result.Exposure),
+			string(result.Severity),
+			strings.Join(result.SharedWith, ";"),
+			result.CreationDate,
+			result.Engine,
+			result.EngineVersion,
+			details.Name,
+			encrypted,
+			details.KMSKeyID,
+			size,
+			result.ParentImage,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write CSV row, %w", err)
+		}
+	}
+
+	writer.Flush()
+
+	err = writer.Error()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write CSV output, %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// formatMarkdown returns a Markdown table of the results, followed by a table of failures when there are any.
+func formatMarkdown(report *Report, _ *outputOptions) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString(
+		"| Account | Region | Type | Identifier | Exposure | Severity | Shared with | Created |\n",
+	)
+	buf.WriteString("| --- | --- | --- | --- | --- | --- | --- | --- |\n")
+
+	for _, result := range report.Results {
+		writeMarkdownRow(&buf,
+			result.Account,
+			result.Region,
+			result.RType.String(),
+			result.Identifier,
+			string(result.Exposure),
+			string(result.Severity),
+			strings.Join(result.SharedWith, ", "),
+			result.CreationDate,
+		)
+	}
+
+	if len(report.Suppressed) > 0 {
+		buf.WriteString("\n### Suppressed\n\n")
+		buf.WriteString("| Account | Region | Type | Identifier | Reason | Expires |\n")
+		buf.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+
+		for _, suppressed := range report.Suppressed {
+			writeMarkdownRow(&buf,
+				suppressed.Account,
+				suppressed.Region,
+				suppressed.RType.String(),
+				suppressed.Identifier,
+				suppressed.Reason,
+				suppressed.Expires,
+			)
+		}
+	}
+
+	if len(report.Failures) > 0 {
+		buf.WriteString("\n### Errors\n\n")
+		buf.WriteString("| Account | Region | Type | Class | Error |\n")
+		buf.WriteString("| --- | --- | --- | --- | --- |\n")
+
+		for _, failure := range report.Failures {
+			writeMarkdownRow(&buf,
+				failure.Account,
+				failure.Region,
+				failure.RType.String(),
+				string(failure.Class),
+				failure.Error,
+			)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeMarkdownRow writes a single table row, escaping characters that would break the table.
+func writeMarkdownRow(buf *bytes.Buffer, cells ...string) {
+	escaper := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	buf.WriteString("|")
+
+	for _, cell := range cells {
+		buf.WriteString(" " + escaper.Replace(cell) + " |")
+	}
+
+	buf.WriteString("\n")
+}
+
+// formatTable returns an aligned, human-readable table of the results, grouped by region and resource type.
+func formatTable(report *Report, _ *outputOptions) ([]byte, error) {
+	const padding = 2
+
+	var buf bytes.Buffer
+
+	if len(report.Results) == 0 {
+		buf.WriteString("no results\n")
+	}
+
+	var writer *tabwriter.Writer
+
+	for idx, result := range report.Results {
+		if idx == 0 ||
+			result.Region != report.Results[idx-1].Region ||
+			result.RType != report.Results[idx-1].RType {
+			if writer != nil {
+				_ = writer.Flush()
+
+				buf.WriteString("\n")
+			}
+
+			_, _ = fmt.Fprintf(&buf, "%s / %s (%d)\n",
+				result.Region, result.RType, countGroup(report.Results[idx:]))
+
+			writer = tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+			_, _ = fmt.Fprintln(
+				writer,
+				"ACCOUNT\tIDENTIFIER\tEXPOSURE\tSEVERITY\tSHARED WITH\tCREATED",
+			)
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			result.Account,
+			result.Identifier,
+			result.Exposure,
+			result.Severity,
+			strings.Join(result.SharedWith, ","),
+			result.CreationDate,
+		)
+	}
+
+	if writer != nil {
+		_ = writer.Flush()
+	}
+
+	if len(report.Suppressed) > 0 {
+		_, _ = fmt.Fprintf(&buf, "\nsuppressed (%d)\n", len(report.Suppressed))
+
+		writer = tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+		_, _ = fmt.Fprintln(writer, "ACCOUNT\tREGION\tTYPE\tIDENTIFIER\tREASON\tEXPIRES")
+
+		for _, suppressed := range report.Suppressed {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				suppressed.Account,
+				suppressed.Region,
+				suppressed.RType,
+				suppressed.Identifier,
+				suppressed.Reason,
+				suppressed.Expires,
+			)
+		}
+
+		_ = writer.Flush()
+	}
+
+	if len(report.Failures) > 0 {
+		_, _ = fmt.Fprintf(&buf, "\nerrors (%d)\n", len(report.Failures))
+
+		writer = tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+		_, _ = fmt.Fprintln(writer, "ACCOUNT\tREGION\tTYPE\tCLASS\tERROR")
+
+		for _, failure := range report.Failures {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+				failure.Account,
+				failure.Region,
+				failure.RType,
+				failure.Class,
+				failure.Error,
+			)
+		}
+
+		_ = writer.Flush()
+	}
+
+	return buf.Bytes(), nil
+}
+
+// countGroup returns the number of leading results that share the region and type of the first one.
+func countGroup(results []Result) int {
+	for idx, result := range results {
+		if result.Region != results[0].Region || result.RType != results[0].RType {
+			return idx
+		}
+	}
+
+	return len(results)
+}
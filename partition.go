@@ -0,0 +1,63 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PartitionAWS is the commercial AWS partition.
+	PartitionAWS = "aws"
+	// PartitionGovCloud is the AWS GovCloud (US) partition.
+	PartitionGovCloud = "aws-us-gov"
+	// PartitionChina is the AWS China partition.
+	PartitionChina = "aws-cn"
+)
+
+// Partitions returns the supported AWS partition names.
+func Partitions() []string {
+	return []string{PartitionAWS, PartitionGovCloud, PartitionChina}
+}
+
+// PartitionRegions returns the generated list of regions of the partition.
+func PartitionRegions(partition string) ([]string, error) {
+	switch partition {
+	case PartitionAWS:
+		return SupportedRegions, nil
+	case PartitionGovCloud:
+		return SupportedGovCloudRegions, nil
+	case PartitionChina:
+		return SupportedChinaRegions, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPartition, partition)
+	}
+}
+
+// RegionPartition returns the partition a region belongs to.
+func RegionPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionGovCloud
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionChina
+	default:
+		return PartitionAWS
+	}
+}
+
+// checkPartition returns an error when the regions do not belong to a single partition,
+// since credentials and the STS endpoint are only valid within one partition.
+func checkPartition(regions []string) error {
+	partition := RegionPartition(regions[0])
+
+	for _, region := range regions[1:] {
+		if RegionPartition(region) != partition {
+			return fmt.Errorf("%w: %s is not in %s", ErrMixedPartitions, region, partition)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_checkPartition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		regions []string
+		wantErr error
+	}{
+		{
+			name:    "commercial regions",
+			regions: []string{"eu-west-1", "us-east-1"},
+			wantErr: nil,
+		},
+		{
+			name:    "GovCloud regions",
+			regions: []string{"us-gov-west-1", "us-gov-east-1"},
+			wantErr: nil,
+		},
+		{
+			name:    "should fail with mixed partitions",
+			regions: []string{"cn-north-1", "eu-west-1"},
+			wantErr: ErrMixedPartitions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkPartition(tt.regions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkPartition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_PartitionRegions(t *testing.T) {
+	t.Parallel()
+
+	for _, partition := range Partitions() {
+		regions, err := PartitionRegions(partition)
+		if err != nil {
+			t.Fatalf("PartitionRegions(%s) error = %v", partition, err)
+		}
+
+		for _, region := range regions {
+			if got := RegionPartition(region); got != partition {
+				t.Errorf("RegionPartition(%s) got = %v, want %v", region, got, partition)
+			}
+		}
+	}
+
+	_, err := PartitionRegions("aws-iso")
+	if !errors.Is(err, ErrUnknownPartition) {
+		t.Errorf("PartitionRegions() error = %v, wantErr %v", err, ErrUnknownPartition)
+	}
+}
+
+func Test_asffResourceIDPartition(t *testing.T) {
+	t.Parallel()
+
+	result := &Result{Account: "42", Identifier: "snap-1", Region: "cn-north-1", RType: SnapshotEBS}
+
+	want := "arn:aws-cn:ec2:cn-north-1::snapshot/snap-1"
+	if got := asffResourceID(result); got != want {
+		t.Errorf("asffResourceID() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// policyWildcard is the principal that grants access to everyone.
+const policyWildcard = "*"
+
+// policyGrant is an Allow statement of a resource policy that grants access outside the owner account.
+type policyGrant struct {
+	// Principals lists the external principals, "*" or an account ID.
+	Principals []string
+	// Statement holds the raw policy statement.
+	Statement json.RawMessage
+}
+
+// isPublic reports whether the grant allows access to everyone.
+func (g policyGrant) isPublic() bool {
+	for _, principal := range g.Principals {
+		if principal == policyWildcard {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scopingConditionKeys are the condition keys that limit a "*" principal to some accounts, organizations
+// or networks, ordered from the most specific one.
+var scopingConditionKeys = []string{
+	"aws:principalaccount",
+	"aws:sourceaccount",
+	"aws:sourceowner",
+	"aws:principalarn",
+	"aws:principalorgid",
+	"aws:sourceorgid",
+	"aws:principalorgpaths",
+	"aws:sourceorgpaths",
+	"aws:sourcevpce",
+	"aws:sourcevpc",
+}
+
+// scopingConditionOperators are the condition operators that only match the listed values.
+var scopingConditionOperators = []string{
+	"ArnEquals",
+	"ArnLike",
+	"StringEquals",
+	"StringEqualsIgnoreCase",
+	"StringLike",
+}
+
+type policyStatement struct {
+	Condition map[string]map[string]json.RawMessage `json:"Condition"`
+	Effect    string                                `json:"Effect"`
+	Principal json.RawMessage                       `json:"Principal"`
+}
+
+// externalGrants parses a resource policy and returns the Allow statements granting access to "*"
+// or to accounts other than the owner. A "*" principal limited by a condition such as aws:PrincipalOrgID
+// is replaced with the condition values, e.g. the organization ID.
+func externalGrants(document string, owner string) ([]policyGrant, error) {
+	var policy struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+
+	err := json.Unmarshal([]byte(document), &policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy, %w", err)
+	}
+
+	statements, err := unmarshalOneOrMany[json.RawMessage](policy.Statement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy statements, %w", err)
+	}
+
+	var grants []policyGrant
+
+	for _, raw := range statements {
+		var statement policyStatement
+
+		err = json.Unmarshal(raw, &statement)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse policy statement, %w", err)
+		}
+
+		if !strings.EqualFold(statement.Effect, "Allow") {
+			continue
+		}
+
+		principals, err := awsPrincipals(statement.Principal)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(principals, policyWildcard) {
+			scope, err := conditionScope(statement.Condition)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(scope) > 0 {
+				principals = slices.DeleteFunc(principals, func(principal string) bool {
+					return principal == policyWildcard
+				})
+				principals = append(principals, scope...)
+			}
+		}
+
+		var external []string
+
+		for _, principal := range principals {
+			account := principalAccount(principal)
+			if account != owner {
+				external = append(external, account)
+			}
+		}
+
+		if len(external) > 0 {
+			grants = append(grants, policyGrant{
+				Principals: external,
+				Statement:  raw,
+			})
+		}
+	}
+
+	return grants, nil
+}
+
+// conditionScope returns the values of the condition that limits a "*" principal, or nil when the
+// statement condition does not limit it. Negated and IfExists operators never limit the principal.
+func conditionScope(condition map[string]map[string]json.RawMessage) ([]string, error) {
+	operators := slices.Sorted(maps.Keys(condition))
+
+	for _, key := range scopingConditionKeys {
+		for _, operator := range operators {
+			if !slices.Contains(
+				scopingConditionOperators,
+				strings.TrimPrefix(operator, "ForAnyValue:"),
+			) {
+				continue
+			}
+
+			for conditionKey, raw := range condition[operator] {
+				if !strings.EqualFold(conditionKey, key) {
+					continue
+				}
+
+				values, err := unmarshalOneOrMany[string](raw)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse policy condition, %w", err)
+				}
+
+				if len(values) == 0 || slices.Contains(values, policyWildcard) {
+					continue
+				}
+
+				return values, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// awsPrincipals returns the AWS principals of a statement, service principals are ignored.
+func awsPrincipals(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var wildcard string
+	if json.Unmarshal(raw, &wildcard) == nil {
+		return []string{wildcard}, nil
+	}
+
+	var principal struct {
+		AWS json.RawMessage `json:"AWS"`
+	}
+
+	err := json.Unmarshal(raw, &principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy principal, %w", err)
+	}
+
+	if len(principal.AWS) == 0 {
+		return nil, nil
+	}
+
+	return unmarshalOneOrMany[string](principal.AWS)
+}
+
+// principalAccount returns the account ID of an AWS principal, keeping "*" and unknown formats as they are.
+func principalAccount(principal string) string {
+	parsed, err := arn.Parse(principal)
+	if err != nil || parsed.AccountID == "" {
+		return principal
+	}
+
+	return parsed.AccountID
+}
+
+// unmarshalOneOrMany decodes a JSON value that holds either a single element or a list of them.
+func unmarshalOneOrMany[T any](raw json.RawMessage) ([]T, error) {
+	var many []T
+	if json.Unmarshal(raw, &many) == nil {
+		return many, nil
+	}
+
+	var one T
+
+	err := json.Unmarshal(raw, &one)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode value, %w", err)
+	}
+
+	return []T{one}, nil
+}
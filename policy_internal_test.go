@@ -0,0 +1,110 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_externalGrants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		document       string
+		owner          string
+		wantPrincipals [][]string
+		wantErr        bool
+	}{
+		{
+			name:     "invalid document",
+			document: "{",
+			owner:    "42",
+			wantErr:  true,
+		},
+		{
+			name:           "wildcard principal",
+			document:       `{"Statement":[{"Effect":"Allow","Principal":"*"}]}`,
+			owner:          "42",
+			wantPrincipals: [][]string{{"*"}},
+		},
+		{
+			name:           "single statement with wildcard AWS principal",
+			document:       `{"Statement":{"Effect":"Allow","Principal":{"AWS":"*"}}}`,
+			owner:          "42",
+			wantPrincipals: [][]string{{"*"}},
+		},
+		{
+			name: "owner and other accounts",
+			document: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":[
+				"arn:aws:iam::42:root","1337","arn:aws:iam::7:user/bob"
+			]}}]}`,
+			owner:          "42",
+			wantPrincipals: [][]string{{"1337", "7"}},
+		},
+		{
+			name: "deny and service statements are ignored",
+			document: `{"Statement":[
+				{"Effect":"Deny","Principal":"*"},
+				{"Effect":"Allow","Principal":{"Service":"lambda.amazonaws.com"}},
+				{"Effect":"Allow","Principal":{"AWS":"42"}}
+			]}`,
+			owner:          "42",
+			wantPrincipals: nil,
+		},
+		{
+			name: "wildcard principal limited to an organization",
+			document: `{"Statement":[{"Effect":"Allow","Principal":"*",
+				"Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"}}}]}`,
+			owner:          "42",
+			wantPrincipals: [][]string{{"o-a1b2c3d4e5"}},
+		},
+		{
+			name: "wildcard principal limited to accounts",
+			document: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},
+				"Condition":{"StringEquals":{"aws:SourceAccount":["42","1337"]}}}]}`,
+			owner:          "42",
+			wantPrincipals: [][]string{{"1337"}},
+		},
+		{
+			name: "wildcard principal limited to the owner account",
+			document: `{"Statement":[{"Effect":"Allow","Principal":"*",
+				"Condition":{"ArnLike":{"aws:PrincipalArn":"arn:aws:iam::42:role/*"}}}]}`,
+			owner:          "42",
+			wantPrincipals: nil,
+		},
+		{
+			name: "wildcard principal with conditions that do not limit it",
+			document: `{"Statement":[{"Effect":"Allow","Principal":"*","Condition":{
+				"StringNotEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"},
+				"StringEqualsIfExists":{"aws:SourceVpce":"vpce-1a2b3c4d"},
+				"StringLike":{"aws:PrincipalAccount":"*"}
+			}}]}`,
+			owner:          "42",
+			wantPrincipals: [][]string{{"*"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := externalGrants(tt.document, tt.owner)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("externalGrants() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			var principals [][]string
+			for _, grant := range got {
+				principals = append(principals, grant.Principals)
+			}
+
+			if !reflect.DeepEqual(principals, tt.wantPrincipals) {
+				t.Errorf("externalGrants() got = %v, want %v", principals, tt.wantPrincipals)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// RetryPolicy configures how the AWS SDK retries failed API calls.
+type RetryPolicy struct {
+	// Mode is the retry mode, standard or adaptive. Adaptive mode also slows down calls after throttling errors.
+	Mode string
+	// MaxAttempts is the maximum number of attempts of each API call, the SDK default is used when 0.
+	MaxAttempts int
+}
+
+// RetryModes returns the supported retry mode names.
+func RetryModes() []string {
+	return []string{string(aws.RetryModeStandard), string(aws.RetryModeAdaptive)}
+}
+
+// parseRetryMode parses a retry mode name, an empty name keeps the SDK default.
+func parseRetryMode(value string) (aws.RetryMode, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	mode, err := aws.ParseRetryMode(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownRetryMode, value)
+	}
+
+	return mode, nil
+}
+
+// tokenBucket allows rate calls per second on average, with bursts of up to burst calls.
+type tokenBucket struct {
+	mu     sync.Mutex
+	burst  float64
+	last   time.Time
+	rate   float64
+	tokens float64
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := math.Max(1, math.Ceil(rate))
+
+	return &tokenBucket{
+		mu:     sync.Mutex{},
+		burst:  burst,
+		last:   time.Now(),
+		rate:   rate,
+		tokens: burst,
+	}
+}
+
+// Wait takes a token, waiting until one is available or the context is done.
+// Tokens are reserved in call order, so a call waiting for a token does not delay the calls made before it.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimits holds the token buckets and throttling error counts of every service in every region.
+// The App shares it between all runners, so runners of the same service and region share a token bucket.
+type rateLimits struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	rate      float64
+	throttles map[string]int
+}
+
+// newRateLimits returns rate limits allowing rate calls per second to each service in each region,
+// a rate of 0 only counts throttling errors.
+func newRateLimits(rate float64) *rateLimits {
+	return &rateLimits{
+		mu:        sync.Mutex{},
+		buckets:   make(map[string]*tokenBucket),
+		rate:      rate,
+		throttles: make(map[string]int),
+	}
+}
+
+// config returns a copy of cfg whose API calls wait for the token bucket of the service in the region of cfg,
+// and whose throttling errors are counted. It applies to every attempt, retries included.
+func (l *rateLimits) config(cfg aws.Config, service string) aws.Config {
+	output := cfg.Copy()
+	key := service + "/" + cfg.Region
+
+	var bucket *tokenBucket
+	if l.rate > 0 {
+		l.mu.Lock()
+
+		bucket = l.buckets[key]
+		if bucket == nil {
+			bucket = newTokenBucket(l.rate)
+			l.buckets[key] = bucket
+		}
+
+		l.mu.Unlock()
+	}
+
+	limit := middleware.FinalizeMiddlewareFunc(
+		"spark:RateLimit",
+		func(
+			ctx context.Context,
+			input middleware.FinalizeInput,
+			next middleware.FinalizeHandler,
+		) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if bucket != nil {
+				err := bucket.Wait(ctx)
+				if err != nil {
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+				}
+			}
+
+			out, metadata, err := next.HandleFinalize(ctx, input)
+			if class, _ := classifyError(err); class == FailureThrottled {
+				l.addThrottle(key)
+			}
+
+			return out, metadata, err
+		},
+	)
+
+	// the slice is shared with cfg, so it is cloned before appending to it
+	output.APIOptions = append(slices.Clone(cfg.APIOptions), func(stack *middleware.Stack) error {
+		return stack.Finalize.Insert(limit, "Retry", middleware.After)
+	})
+
+	return output
+}
+
+func (l *rateLimits) addThrottle(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.throttles[key]++
+}
+
+// throttleCounts returns the number of throttling errors of each service and region, keyed by service/region.
+func (l *rateLimits) throttleCounts() map[string]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return maps.Clone(l.throttles)
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func Test_parseRetryMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		want    aws.RetryMode
+		wantErr bool
+	}{
+		{
+			name:    "empty keeps the SDK default",
+			value:   "",
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name:    "standard",
+			value:   "standard",
+			want:    aws.RetryModeStandard,
+			wantErr: false,
+		},
+		{
+			name:    "adaptive",
+			value:   "adaptive",
+			want:    aws.RetryModeAdaptive,
+			wantErr: false,
+		},
+		{
+			name:    "should fail with unknown mode",
+			value:   "legacy",
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseRetryMode(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRetryMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrUnknownRetryMode) {
+				t.Errorf("parseRetryMode() error = %v, want %v", err, ErrUnknownRetryMode)
+			}
+
+			if got != tt.want {
+				t.Errorf("parseRetryMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tokenBucket(t *testing.T) {
+	t.Parallel()
+
+	const rate = 50
+
+	bucket := newTokenBucket(rate)
+
+	// the bucket starts full, the calls after the burst wait for new tokens
+	start := time.Now()
+
+	for range rate + 5 {
+		err := bucket.Wait(t.Context())
+		if err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+
+	// 5 calls over the burst take 100ms, minus the tokens added while the first calls were made
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Wait() took %v, want about 100ms for 5 calls over the burst", elapsed)
+	}
+
+	slow := newTokenBucket(1)
+
+	err := slow.Wait(t.Context())
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err = slow.Wait(ctx)
+	if !errors.Is(err, ErrCtxCancelled) {
+		t.Errorf("Wait() error = %v, want %v", err, ErrCtxCancelled)
+	}
+}
+
+func Test_rateLimitsShareBuckets(t *testing.T) {
+	t.Parallel()
+
+	limits := newRateLimits(10)
+
+	limits.config(aws.Config{Region: "eu-west-1"}, serviceEC2)
+	limits.config(aws.Config{Region: "eu-west-1"}, serviceEC2)
+	limits.config(aws.Config{Region: "eu-west-1"}, serviceRDS)
+	limits.config(aws.Config{Region: "eu-central-1"}, serviceEC2)
+
+	if len(limits.buckets) != 3 {
+		t.Errorf("buckets = %v, want one per service and region", limits.buckets)
+	}
+
+	if len(newRateLimits(0).config(aws.Config{}, serviceEC2).APIOptions) != 1 {
+		t.Error("config() without a rate should still count throttling errors")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// regionNotOptedIn is the opt-in status of regions that are disabled for the account.
+const regionNotOptedIn = "not-opted-in"
+
+// optInRegions are the regions that are disabled by default and have to be enabled for each account.
+var optInRegions = []string{
+	"af-south-1",
+	"ap-east-1",
+	"ap-east-2",
+	"ap-south-2",
+	"ap-southeast-3",
+	"ap-southeast-4",
+	"ap-southeast-5",
+	"ap-southeast-6",
+	"ap-southeast-7",
+	"ca-west-1",
+	"eu-central-2",
+	"eu-south-1",
+	"eu-south-2",
+	"il-central-1",
+	"me-central-1",
+	"me-south-1",
+	"mx-central-1",
+}
+
+var _ regionsClient = (*ec2.Client)(nil)
+
+type regionsClient interface {
+	DescribeRegions(
+		ctx context.Context,
+		params *ec2.DescribeRegionsInput,
+		optFns ...func(*ec2.Options),
+	) (*ec2.DescribeRegionsOutput, error)
+}
+
+// discoverRegions returns the regions enabled for the caller, skipping regions that are not opted in.
+// The fallback regions are returned when the regions cannot be listed.
+func discoverRegions(ctx context.Context, client regionsClient, fallback []string) []string {
+	output, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{
+		AllRegions:  aws.Bool(true),
+		DryRun:      nil,
+		Filters:     nil,
+		RegionNames: nil,
+	})
+	if err != nil {
+		slog.Warn("failed to discover regions, using the embedded list",
+			slog.String("error", err.Error()),
+		)
+
+		return fallback
+	}
+
+	var enabled, skipped []string
+
+	for _, region := range output.Regions {
+		if aws.ToString(region.OptInStatus) == regionNotOptedIn {
+			skipped = append(skipped, aws.ToString(region.RegionName))
+
+			continue
+		}
+
+		enabled = append(enabled, aws.ToString(region.RegionName))
+	}
+
+	if len(enabled) == 0 {
+		slog.Warn("no enabled regions discovered, using the embedded list")
+
+		return fallback
+	}
+
+	if len(skipped) > 0 {
+		slices.Sort(skipped)
+
+		slog.Info("skipping regions that are not opted in",
+			slog.String("regions", strings.Join(skipped, ",")),
+		)
+	}
+
+	slices.Sort(enabled)
+
+	return enabled
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+type mockRegionsClient struct {
+	mockRegions            []types.Region
+	mockDescribeRegionsErr error
+}
+
+func (m mockRegionsClient) DescribeRegions(
+	_ context.Context,
+	_ *ec2.DescribeRegionsInput,
+	_ ...func(*ec2.Options),
+) (*ec2.DescribeRegionsOutput, error) {
+	return &ec2.DescribeRegionsOutput{
+		Regions: m.mockRegions,
+	}, m.mockDescribeRegionsErr
+}
+
+var _ regionsClient = (*mockRegionsClient)(nil)
+
+func Test_discoverRegions(t *testing.T) {
+	t.Parallel()
+
+	fallback := []string{"eu-west-1", "us-east-1"}
+
+	tests := []struct {
+		name   string
+		client regionsClient
+		want   []string
+	}{
+		{
+			name: "should skip regions that are not opted in",
+			client: &mockRegionsClient{
+				mockRegions: []types.Region{
+					{
+						OptInStatus: aws.String("opt-in-not-required"),
+						RegionName:  aws.String("us-east-1"),
+					},
+					{OptInStatus: aws.String("not-opted-in"), RegionName: aws.String("ap-east-1")},
+					{OptInStatus: aws.String("opted-in"), RegionName: aws.String("af-south-1")},
+					{
+						OptInStatus: aws.String("opt-in-not-required"),
+						RegionName:  aws.String("eu-west-1"),
+					},
+				},
+				mockDescribeRegionsErr: nil,
+			},
+			want: []string{"af-south-1", "eu-west-1", "us-east-1"},
+		},
+		{
+			name: "should fall back when api returns error",
+			client: &mockRegionsClient{
+				mockRegions:            nil,
+				mockDescribeRegionsErr: errors.New("some error"),
+			},
+			want: fallback,
+		},
+		{
+			name: "should fall back when no region is enabled",
+			client: &mockRegionsClient{
+				mockRegions: []types.Region{
+					{OptInStatus: aws.String("not-opted-in"), RegionName: aws.String("ap-east-1")},
+				},
+				mockDescribeRegionsErr: nil,
+			},
+			want: fallback,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := discoverRegions(t.Context(), tt.client, fallback)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("discoverRegions() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
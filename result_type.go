@@ -3,10 +3,79 @@
 
 package spark
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // Result represents the output of a scanning operation, including metadata about the scanned resource.
 type Result struct {
-	CreationDate string     `json:"creationDate"`
-	Identifier   string     `json:"identifier"`
-	Region       string     `json:"region"`
-	RType        RunnerType `json:"type"`
+	Account          string            `json:"account"`
+	BackingSnapshots []string          `json:"backingSnapshots,omitempty"`
+	CreationDate     string            `json:"creationDate"`
+	Details          *Details          `json:"details,omitempty"`
+	Engine           string            `json:"engine,omitempty"`
+	EngineVersion    string            `json:"engineVersion,omitempty"`
+	Exposure         Exposure          `json:"exposure,omitempty"`
+	FirstSeen        string            `json:"firstSeen,omitempty"`
+	Identifier       string            `json:"identifier"`
+	ParentImage      string            `json:"parentImage,omitempty"`
+	PolicyStatements []json.RawMessage `json:"policyStatements,omitempty"`
+	Region           string            `json:"region"`
+	RType            RunnerType        `json:"type"`
+	Secrets          []SecretFinding   `json:"secrets,omitempty"`
+	Severity         Severity          `json:"severity,omitempty"`
+	SharedWith       []string          `json:"sharedWith,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
+}
+
+// summary describes the result in a single sentence.
+func (r *Result) summary() string {
+	message := fmt.Sprintf("%s %s in account %s (%s)",
+		r.RType, r.Identifier, r.Account, r.Region)
+
+	if len(r.Secrets) > 0 {
+		message += fmt.Sprintf(" contains %d possible secret(s) and", len(r.Secrets))
+	}
+
+	switch r.Exposure {
+	case ExposurePublic:
+		return message + " is public"
+	case ExposureShared:
+		if len(r.SharedWith) == 0 {
+			return message + " is shared"
+		}
+
+		return fmt.Sprintf("%s is shared with %s", message, strings.Join(r.SharedWith, ", "))
+	case ExposurePrivate:
+		return message + " is private"
+	case ExposureUnknown:
+		return message + " has unknown exposure"
+	default:
+		return message + " was found"
+	}
+}
+
+// Details holds optional metadata about the scanned resource that helps to triage a result.
+type Details struct {
+	// ARN is the ARN of the resource, set by the scanners whose Identifier can be a name.
+	ARN string `json:"arn,omitempty"`
+	// ContentNotScanned is set when the content of the resource could not be fetched to search it for secrets.
+	ContentNotScanned bool   `json:"contentNotScanned,omitempty"`
+	Description       string `json:"description,omitempty"`
+	Encrypted         *bool  `json:"encrypted,omitempty"`
+	KMSKeyID          string `json:"kmsKeyId,omitempty"`
+	Name              string `json:"name,omitempty"`
+	OwnerAlias        string `json:"ownerAlias,omitempty"`
+	SizeGiB           int64  `json:"sizeGiB,omitempty"`
+}
+
+// newDetails returns a pointer to details, or nil when no metadata is known.
+func newDetails(details Details) *Details {
+	if details == (Details{}) { //nolint:exhaustruct
+		return nil
+	}
+
+	return &details
 }
@@ -19,6 +19,25 @@ func (i RunnerType) MarshalJSON() ([]byte, error) {
 	return json.Marshal(i.String()) //nolint:wrapcheck
 }
 
+// UnmarshalJSON parses a RunnerType from its string representation, ignoring case.
+func (i *RunnerType) UnmarshalJSON(data []byte) error {
+	var name string
+
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return fmt.Errorf("failed to parse runner type, %w", err)
+	}
+
+	runners := GetRunners([]string{name})
+	if len(runners) == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownRunnerType, name)
+	}
+
+	*i = runners[0]
+
+	return nil
+}
+
 const (
 	// ImageAMI represents a scanner for Amazon Machine Images (AMIs).
 	ImageAMI RunnerType = iota + 1 // AMI
@@ -28,11 +47,48 @@ const (
 	SnapshotRDS // snapshotsRDS
 	// DocumentSSM represents a scanner for SSM documents.
 	DocumentSSM
+	// LayerLambda represents a scanner for Lambda layer versions.
+	LayerLambda // layersLambda
+	// SnapshotRedshift represents a scanner for Redshift cluster snapshots.
+	SnapshotRedshift // snapshotsRedshift
+	// SnapshotDocDB represents a scanner for DocumentDB cluster snapshots.
+	SnapshotDocDB // snapshotsDocDB
+	// SnapshotNeptune represents a scanner for Neptune cluster snapshots.
+	SnapshotNeptune // snapshotsNeptune
+	// RepositoryECR represents a scanner for public ECR and ECR Public repositories.
+	RepositoryECR // repositoriesECR
 )
 
+// description returns a short, human-readable description of the findings reported by the RunnerType.
+func (i RunnerType) description() string {
+	switch i {
+	case ImageAMI:
+		return "AMI is shared outside the owner account"
+	case SnapshotEBS:
+		return "EBS snapshot is shared outside the owner account"
+	case SnapshotRDS:
+		return "RDS snapshot is shared outside the owner account"
+	case DocumentSSM:
+		return "SSM document is shared outside the owner account"
+	case LayerLambda:
+		return "Lambda layer version is shared outside the owner account"
+	case SnapshotRedshift:
+		return "Redshift snapshot is shared outside the owner account"
+	case SnapshotDocDB:
+		return "DocumentDB cluster snapshot is shared outside the owner account"
+	case SnapshotNeptune:
+		return "Neptune cluster snapshot is shared outside the owner account"
+	case RepositoryECR:
+		return "ECR repository is publicly accessible"
+	default:
+		return i.String()
+	}
+}
+
 var (
-	_ json.Marshaler = (*RunnerType)(nil)
-	_ fmt.Stringer   = (*RunnerType)(nil)
+	_ json.Marshaler   = (*RunnerType)(nil)
+	_ json.Unmarshaler = (*RunnerType)(nil)
+	_ fmt.Stringer     = (*RunnerType)(nil)
 )
 
 // Runner defines an interface for scanning and retrieving runner metadata.
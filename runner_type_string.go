@@ -13,11 +13,16 @@ func _() {
 	_ = x[SnapshotEBS-2]
 	_ = x[SnapshotRDS-3]
 	_ = x[DocumentSSM-4]
+	_ = x[LayerLambda-5]
+	_ = x[SnapshotRedshift-6]
+	_ = x[SnapshotDocDB-7]
+	_ = x[SnapshotNeptune-8]
+	_ = x[RepositoryECR-9]
 }
 
-const _RunnerType_name = "AMIsnapshotsEBSsnapshotsRDSDocumentSSM"
+const _RunnerType_name = "AMIsnapshotsEBSsnapshotsRDSDocumentSSMlayersLambdasnapshotsRedshiftsnapshotsDocDBsnapshotsNeptunerepositoriesECR"
 
-var _RunnerType_index = [...]uint8{0, 3, 15, 27, 38}
+var _RunnerType_index = [...]uint8{0, 3, 15, 27, 38, 50, 67, 81, 97, 112}
 
 func (i RunnerType) String() string {
 	i -= 1
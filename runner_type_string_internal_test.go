@@ -18,6 +18,11 @@ func Test_runnerType_String(t *testing.T) {
 			rType: ImageAMI,
 			want:  "AMI",
 		},
+		{
+			name:  "Lambda layers",
+			rType: LayerLambda,
+			want:  "layersLambda",
+		},
 		{
 			name:  "unknown",
 			rType: RunnerType(-1),
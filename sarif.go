@@ -0,0 +1,242 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	// sarifFingerprintKey names the partial fingerprint that identifies a resource across runs.
+	sarifFingerprintKey = "resourceFingerprint/v1"
+	toolName            = "spark"
+	toolInformationURI  = "https://github.com/wakeful/spark"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"` //nolint:tagliatelle
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Properties map[string]string `json:"properties"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints"`
+	Properties          Result             `json:"properties"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+	Name               string `json:"name"`
+}
+
+// formatSARIF returns a SARIF 2.1.0 log from a scan Report, with one rule per RunnerType
+// and one result per scanned resource.
+func formatSARIF(report *Report, opts *outputOptions) ([]byte, error) {
+	rules, ruleIndex := sarifRules()
+
+	results := make([]sarifResult, 0, len(report.Results)+len(report.Suppressed))
+	for _, result := range report.Results {
+		results = append(results, newSARIFResult(&result, ruleIndex, nil))
+	}
+
+	for _, suppressed := range report.Suppressed {
+		results = append(results, newSARIFResult(&suppressed.Result, ruleIndex, []sarifSuppression{
+			{
+				Kind:          "external",
+				Justification: suppressed.Reason,
+			},
+		}))
+	}
+
+	notifications := make([]sarifNotification, 0, len(report.Failures))
+	for _, failure := range report.Failures {
+		notifications = append(notifications, sarifNotification{
+			Level:   "error",
+			Message: sarifMessage{Text: failure.Error},
+			Properties: map[string]string{
+				"account": failure.Account,
+				"class":   string(failure.Class),
+				"region":  failure.Region,
+				"type":    failure.RType.String(),
+			},
+		})
+	}
+
+	marshal, err := json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           toolName,
+						InformationURI: toolInformationURI,
+						Version:        opts.toolVersion,
+						Rules:          rules,
+					},
+				},
+				Invocations: []sarifInvocation{
+					{
+						ExecutionSuccessful:        len(report.Failures) == 0,
+						ToolExecutionNotifications: notifications,
+					},
+				},
+				Results: results,
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SARIF output, %w", err)
+	}
+
+	return marshal, nil
+}
+
+// newSARIFResult maps a Result to a SARIF result, suppressions are set for suppressed results.
+func newSARIFResult(
+	result *Result,
+	ruleIndex map[RunnerType]int,
+	suppressions []sarifSuppression,
+) sarifResult {
+	return sarifResult{
+		RuleID:    result.RType.String(),
+		RuleIndex: ruleIndex[result.RType],
+		Level:     sarifLevel(result),
+		Message:   sarifMessage{Text: result.summary()},
+		Locations: []sarifLocation{
+			{
+				LogicalLocations: []sarifLogicalLocation{
+					{
+						FullyQualifiedName: strings.Join(
+							[]string{result.Account, result.Region, result.Identifier},
+							"/",
+						),
+						Kind: "resource",
+						Name: result.Identifier,
+					},
+				},
+			},
+		},
+		PartialFingerprints: map[string]string{
+			sarifFingerprintKey: sarifFingerprint(result),
+		},
+		Properties:   *result,
+		Suppressions: suppressions,
+	}
+}
+
+// sarifRules returns a rule for every supported RunnerType, along with the index of each rule.
+func sarifRules() ([]sarifRule, map[RunnerType]int) {
+	supported := GetRunners(GetSupportedScanners())
+
+	rules := make([]sarifRule, 0, len(supported))
+	index := make(map[RunnerType]int, len(supported))
+
+	for idx, rType := range supported {
+		index[rType] = idx
+		rules = append(rules, sarifRule{
+			ID:               rType.String(),
+			Name:             rType.String(),
+			ShortDescription: sarifMessage{Text: rType.description()},
+			HelpURI:          toolInformationURI,
+		})
+	}
+
+	return rules, index
+}
+
+// sarifLevel maps the severity of a result to a SARIF level,
+// falling back to its exposure when the result was not scored.
+func sarifLevel(result *Result) string {
+	switch result.Severity {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	case SeverityLow, SeverityInfo:
+		return "note"
+	}
+
+	switch result.Exposure {
+	case ExposurePublic:
+		return "error"
+	case ExposureShared, ExposureUnknown:
+		return "warning"
+	case ExposurePrivate:
+		return "note"
+	default:
+		return "warning"
+	}
+}
+
+// sarifFingerprint returns a stable fingerprint of the scanned resource based on its account, region, and identifier.
+func sarifFingerprint(result *Result) string {
+	sum := sha256.Sum256([]byte(strings.Join(
+		[]string{result.Account, result.Region, result.Identifier},
+		"|",
+	)))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/wakeful/spark"
+)
+
+type sarifOutput struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Version string `json:"version"`
+				Rules   []struct {
+					ID string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Invocations []struct {
+			ExecutionSuccessful bool `json:"executionSuccessful"`
+		} `json:"invocations"`
+		Results []struct {
+			RuleID              string            `json:"ruleId"`
+			RuleIndex           int               `json:"ruleIndex"`
+			Level               string            `json:"level"`
+			PartialFingerprints map[string]string `json:"partialFingerprints"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+func Test_PrepareSARIF(t *testing.T) {
+	t.Parallel()
+
+	report := &spark.Report{
+		Results: []spark.Result{
+			{
+				Account:    "42",
+				Exposure:   spark.ExposurePublic,
+				Identifier: "snap-1",
+				Region:     "eu-west-1",
+				RType:      spark.SnapshotEBS,
+			},
+			{
+				Account:    "42",
+				Exposure:   spark.ExposureShared,
+				Identifier: "snap-1",
+				Region:     "eu-west-2",
+				RType:      spark.SnapshotEBS,
+				SharedWith: []string{"1337"},
+			},
+		},
+		Failures: []spark.Failure{
+			{
+				Account: "42",
+				Class:   spark.FailureAccessDenied,
+				Error:   "denied",
+				Region:  "eu-west-1",
+				RType:   spark.ImageAMI,
+			},
+		},
+	}
+
+	first, err := spark.PrepareOutput(
+		report,
+		spark.WithOutputFormat(spark.OutputSARIF),
+		spark.WithToolVersion("v1.2.3"),
+	)
+	if err != nil {
+		t.Fatalf("PrepareOutput() error = %v", err)
+	}
+
+	second, err := spark.PrepareOutput(
+		report,
+		spark.WithOutputFormat(spark.OutputSARIF),
+		spark.WithToolVersion("v1.2.3"),
+	)
+	if err != nil {
+		t.Fatalf("PrepareOutput() error = %v", err)
+	}
+
+	if string(first) != string(second) {
+		t.Errorf("PrepareOutput() is not deterministic")
+	}
+
+	var got sarifOutput
+
+	err = json.Unmarshal(first, &got)
+	if err != nil {
+		t.Fatalf("failed to parse SARIF output, %v", err)
+	}
+
+	if got.Version != "2.1.0" || len(got.Runs) != 1 {
+		t.Fatalf("PrepareOutput() version = %s, runs = %d", got.Version, len(got.Runs))
+	}
+
+	run := got.Runs[0]
+	if run.Tool.Driver.Version != "v1.2.3" {
+		t.Errorf("PrepareOutput() tool version = %s, want v1.2.3", run.Tool.Driver.Version)
+	}
+
+	if len(run.Tool.Driver.Rules) != len(spark.GetSupportedScanners()) {
+		t.Errorf("PrepareOutput() rules = %d, want %d",
+			len(run.Tool.Driver.Rules), len(spark.GetSupportedScanners()))
+	}
+
+	if len(run.Invocations) != 1 || run.Invocations[0].ExecutionSuccessful {
+		t.Errorf("PrepareOutput() invocation should report failures")
+	}
+
+	if len(run.Results) != 2 {
+		t.Fatalf("PrepareOutput() results = %d, want 2", len(run.Results))
+	}
+
+	for _, result := range run.Results {
+		if result.RuleID != "snapshotsEBS" ||
+			run.Tool.Driver.Rules[result.RuleIndex].ID != result.RuleID {
+			t.Errorf("PrepareOutput() result rule = %s (%d)", result.RuleID, result.RuleIndex)
+		}
+	}
+
+	if run.Results[0].Level != "error" || run.Results[1].Level != "warning" {
+		t.Errorf("PrepareOutput() levels = %s, %s", run.Results[0].Level, run.Results[1].Level)
+	}
+
+	fingerprint := run.Results[0].PartialFingerprints["resourceFingerprint/v1"]
+	if fingerprint == "" ||
+		fingerprint == run.Results[1].PartialFingerprints["resourceFingerprint/v1"] {
+		t.Errorf("PrepareOutput() fingerprints should be set and differ between regions")
+	}
+}
@@ -6,9 +6,11 @@ package spark
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
 var (
@@ -18,6 +20,11 @@ var (
 
 type amiClient interface {
 	ec2.DescribeImagesAPIClient
+	DescribeImageAttribute(
+		ctx context.Context,
+		params *ec2.DescribeImageAttributeInput,
+		optFns ...func(*ec2.Options),
+	) (*ec2.DescribeImageAttributeOutput, error)
 }
 
 // AMIScan scans Amazon Machine Images in a region using an EC2 client.
@@ -66,14 +73,129 @@ func (s *AMIScan) Scan(ctx context.Context, target string) ([]Result, error) {
 		}
 
 		for _, image := range page.Images {
+			exposure, sharedWith := s.exposure(ctx, &image, target)
+
 			output = append(output, Result{
-				CreationDate: *image.CreationDate,
-				Identifier:   *image.ImageId,
-				Region:       s.region,
-				RType:        s.RunType(),
+				Account:          "",
+				BackingSnapshots: amiSnapshotIDs(&image),
+				CreationDate:     *image.CreationDate,
+				Details:          amiDetails(&image),
+				Engine:           "",
+				EngineVersion:    "",
+				Exposure:         exposure,
+				FirstSeen:        "",
+				Identifier:       *image.ImageId,
+				ParentImage:      "",
+				PolicyStatements: nil,
+				Region:           s.region,
+				RType:            s.RunType(),
+				Secrets:          nil,
+				Severity:         "",
+				SharedWith:       sharedWith,
+				Tags:             tagsToMap(image.Tags, ec2Tag),
 			})
 		}
 	}
 
 	return output, nil
 }
+
+// exposure returns who can launch the image, based on its launch permissions.
+func (s *AMIScan) exposure(
+	ctx context.Context,
+	image *types.Image,
+	target string,
+) (Exposure, []string) {
+	if aws.ToBool(image.Public) {
+		return ExposurePublic, nil
+	}
+
+	if isExternalScan(ctx, target) {
+		return externalExposure(ctx)
+	}
+
+	attribute, err := s.client.DescribeImageAttribute(ctx, &ec2.DescribeImageAttributeInput{
+		Attribute: types.ImageAttributeNameLaunchPermission,
+		ImageId:   image.ImageId,
+		DryRun:    nil,
+	})
+	if err != nil {
+		slog.Debug("failed to describe AMI launch permissions",
+			slog.String("error", err.Error()),
+			slog.String("id", *image.ImageId),
+			slog.String("region", s.region),
+		)
+
+		return ExposureUnknown, nil
+	}
+
+	permissions := make([]string, 0, len(attribute.LaunchPermissions))
+
+	for _, permission := range attribute.LaunchPermissions {
+		switch {
+		case permission.Group == types.PermissionGroupAll:
+			permissions = append(permissions, permissionAll)
+		case permission.UserId != nil:
+			permissions = append(permissions, *permission.UserId)
+		case permission.OrganizationArn != nil:
+			permissions = append(permissions, *permission.OrganizationArn)
+		case permission.OrganizationalUnitArn != nil:
+			permissions = append(permissions, *permission.OrganizationalUnitArn)
+		}
+	}
+
+	return exposureFromPermissions(permissions)
+}
+
+// amiDetails returns the triage metadata of an AMI, its size and encryption come from the EBS block devices.
+func amiDetails(image *types.Image) *Details {
+	var (
+		encrypted *bool
+		size      int64
+	)
+
+	for _, mapping := range image.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+
+		size += int64(aws.ToInt32(mapping.Ebs.VolumeSize))
+
+		if mapping.Ebs.Encrypted != nil {
+			encrypted = aws.Bool(
+				aws.ToBool(mapping.Ebs.Encrypted) && (encrypted == nil || *encrypted),
+			)
+		}
+	}
+
+	return newDetails(Details{
+		ARN:               "",
+		ContentNotScanned: false,
+		Description:       aws.ToString(image.Description),
+		Encrypted:         encrypted,
+		KMSKeyID:          "",
+		Name:              aws.ToString(image.Name),
+		OwnerAlias:        aws.ToString(image.ImageOwnerAlias),
+		SizeGiB:           size,
+	})
+}
+
+// amiSnapshotIDs returns the IDs of the EBS snapshots backing the block devices of an AMI.
+func amiSnapshotIDs(image *types.Image) []string {
+	var output []string
+
+	for _, mapping := range image.BlockDeviceMappings {
+		if mapping.Ebs == nil || aws.ToString(mapping.Ebs.SnapshotId) == "" {
+			continue
+		}
+
+		output = append(output, *mapping.Ebs.SnapshotId)
+	}
+
+	return output
+}
+
+// ec2Tag returns the key and value of an EC2 tag.
+func ec2Tag(tag types.Tag) (*string, *string) {
+	return tag.Key, tag.Value
+}
@@ -16,8 +16,10 @@ import (
 )
 
 type mockAMIClient struct {
-	mockImages   []types.Image
-	mockImageErr error
+	mockImages            []types.Image
+	mockImageErr          error
+	mockLaunchPermissions []types.LaunchPermission
+	mockImageAttributeErr error
 }
 
 func (m *mockAMIClient) DescribeImages(
@@ -28,6 +30,16 @@ func (m *mockAMIClient) DescribeImages(
 	return &ec2.DescribeImagesOutput{Images: m.mockImages}, m.mockImageErr
 }
 
+func (m *mockAMIClient) DescribeImageAttribute(
+	_ context.Context,
+	_ *ec2.DescribeImageAttributeInput,
+	_ ...func(*ec2.Options),
+) (*ec2.DescribeImageAttributeOutput, error) {
+	return &ec2.DescribeImageAttributeOutput{
+		LaunchPermissions: m.mockLaunchPermissions,
+	}, m.mockImageAttributeErr
+}
+
 var _ amiClient = (*mockAMIClient)(nil)
 
 func Test_amiImageScan_scan(t *testing.T) {
@@ -96,9 +108,182 @@ func Test_amiImageScan_scan(t *testing.T) {
 			want: []Result{
 				{
 					CreationDate: "properly formatted date",
+					Exposure:     ExposurePrivate,
+					Identifier:   "test-image-id",
+					Region:       "eu-west-1",
+					RType:        ImageAMI,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should succeed with AMI details",
+			ctx:  t.Context(),
+			client: &mockAMIClient{
+				mockImages: []types.Image{
+					{
+						BlockDeviceMappings: []types.BlockDeviceMapping{
+							{
+								Ebs: &types.EbsBlockDevice{
+									Encrypted:  aws.Bool(true),
+									SnapshotId: aws.String("snap-1"),
+									VolumeSize: aws.Int32(8),
+								},
+							},
+							{
+								Ebs: &types.EbsBlockDevice{
+									Encrypted:  aws.Bool(false),
+									SnapshotId: aws.String("snap-2"),
+									VolumeSize: aws.Int32(100),
+								},
+							},
+							{
+								VirtualName: aws.String("ephemeral0"),
+							},
+						},
+						CreationDate:    aws.String("properly formatted date"),
+						Description:     aws.String("test description"),
+						ImageId:         aws.String("test-image-id"),
+						ImageOwnerAlias: aws.String("amazon"),
+						Name:            aws.String("test-name"),
+					},
+				},
+				mockImageErr: nil,
+			},
+			region: "eu-west-1",
+			target: "self",
+			want: []Result{
+				{
+					BackingSnapshots: []string{"snap-1", "snap-2"},
+					CreationDate:     "properly formatted date",
+					Details: &Details{
+						Description: "test description",
+						Encrypted:   aws.Bool(false),
+						Name:        "test-name",
+						OwnerAlias:  "amazon",
+						SizeGiB:     108,
+					},
+					Exposure:   ExposurePrivate,
+					Identifier: "test-image-id",
+					Region:     "eu-west-1",
+					RType:      ImageAMI,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should report public AMI without reading launch permissions",
+			ctx:  t.Context(),
+			client: &mockAMIClient{
+				mockImages: []types.Image{
+					{
+						CreationDate: aws.String("properly formatted date"),
+						ImageId:      aws.String("test-image-id"),
+						Public:       aws.Bool(true),
+					},
+				},
+				mockImageErr:          nil,
+				mockImageAttributeErr: errors.New("some error"),
+			},
+			region: "eu-west-1",
+			target: "self",
+			want: []Result{
+				{
+					CreationDate: "properly formatted date",
+					Exposure:     ExposurePublic,
+					Identifier:   "test-image-id",
+					Region:       "eu-west-1",
+					RType:        ImageAMI,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should report AMI shared with accounts",
+			ctx:  t.Context(),
+			client: &mockAMIClient{
+				mockImages: []types.Image{
+					{
+						CreationDate: aws.String("properly formatted date"),
+						ImageId:      aws.String("test-image-id"),
+					},
+				},
+				mockImageErr: nil,
+				mockLaunchPermissions: []types.LaunchPermission{
+					{UserId: aws.String("1337")},
+					{
+						OrganizationArn: aws.String(
+							"arn:aws:organizations::42:organization/o-example",
+						),
+					},
+				},
+			},
+			region: "eu-west-1",
+			target: "self",
+			want: []Result{
+				{
+					CreationDate: "properly formatted date",
+					Exposure:     ExposureShared,
+					Identifier:   "test-image-id",
+					Region:       "eu-west-1",
+					RType:        ImageAMI,
+					SharedWith: []string{
+						"1337",
+						"arn:aws:organizations::42:organization/o-example",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should report unknown exposure when launch permissions cannot be read",
+			ctx:  t.Context(),
+			client: &mockAMIClient{
+				mockImages: []types.Image{
+					{
+						CreationDate: aws.String("properly formatted date"),
+						ImageId:      aws.String("test-image-id"),
+					},
+				},
+				mockImageErr:          nil,
+				mockImageAttributeErr: errors.New("some error"),
+			},
+			region: "eu-west-1",
+			target: "self",
+			want: []Result{
+				{
+					CreationDate: "properly formatted date",
+					Exposure:     ExposureUnknown,
+					Identifier:   "test-image-id",
+					Region:       "eu-west-1",
+					RType:        ImageAMI,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should report AMI of another account as shared with the caller",
+			ctx:  withCaller(t.Context(), "1337"),
+			client: &mockAMIClient{
+				mockImages: []types.Image{
+					{
+						CreationDate: aws.String("properly formatted date"),
+						ImageId:      aws.String("test-image-id"),
+					},
+				},
+				mockImageErr:          nil,
+				mockImageAttributeErr: errors.New("some error"),
+			},
+			region: "eu-west-1",
+			target: "42",
+			want: []Result{
+				{
+					CreationDate: "properly formatted date",
+					Exposure:     ExposureShared,
 					Identifier:   "test-image-id",
 					Region:       "eu-west-1",
 					RType:        ImageAMI,
+					SharedWith:   []string{"1337"},
 				},
 			},
 			wantErr: false,
@@ -6,10 +6,12 @@ package spark
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 )
 
 var (
@@ -19,6 +21,11 @@ var (
 
 type ebsSnapshotClient interface {
 	ec2.DescribeSnapshotsAPIClient
+	DescribeSnapshotAttribute(
+		ctx context.Context,
+		params *ec2.DescribeSnapshotAttributeInput,
+		optFns ...func(*ec2.Options),
+	) (*ec2.DescribeSnapshotAttributeOutput, error)
 }
 
 // EBSSnapshotScan scans EBS snapshots in a region using an EC2 client.
@@ -45,6 +52,11 @@ func NewEBSSnapshotRunner(cfg aws.Config) *EBSSnapshotScan {
 func (s *EBSSnapshotScan) Scan(ctx context.Context, target string) ([]Result, error) {
 	var output []Result
 
+	public, err := s.publicSnapshots(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
 	paginator := ec2.NewDescribeSnapshotsPaginator(s.client, &ec2.DescribeSnapshotsInput{
 		DryRun:              nil,
 		Filters:             nil,
@@ -65,14 +77,127 @@ func (s *EBSSnapshotScan) Scan(ctx context.Context, target string) ([]Result, er
 		}
 
 		for _, snapshot := range page.Snapshots {
+			exposure, sharedWith := s.exposure(ctx, &snapshot, target, public)
+
 			output = append(output, Result{
-				CreationDate: snapshot.CompletionTime.Format(time.RFC3339),
-				Identifier:   *snapshot.SnapshotId,
-				Region:       s.region,
-				RType:        s.RunType(),
+				Account:          "",
+				BackingSnapshots: nil,
+				CreationDate:     snapshot.CompletionTime.Format(time.RFC3339),
+				Details:          ebsSnapshotDetails(&snapshot),
+				Engine:           "",
+				EngineVersion:    "",
+				Exposure:         exposure,
+				FirstSeen:        "",
+				Identifier:       *snapshot.SnapshotId,
+				ParentImage:      "",
+				PolicyStatements: nil,
+				Region:           s.region,
+				RType:            s.RunType(),
+				Secrets:          nil,
+				Severity:         "",
+				SharedWith:       sharedWith,
+				Tags:             tagsToMap(snapshot.Tags, ec2Tag),
 			})
 		}
 	}
 
 	return output, nil
 }
+
+// publicSnapshots returns the IDs of the public snapshots of the target when it is scanned from another account.
+// The snapshots listed there are either public or shared with the caller, and the permissions cannot be read.
+func (s *EBSSnapshotScan) publicSnapshots(
+	ctx context.Context,
+	target string,
+) (map[string]struct{}, error) {
+	if !isExternalScan(ctx, target) {
+		return nil, nil
+	}
+
+	output := make(map[string]struct{})
+
+	paginator := ec2.NewDescribeSnapshotsPaginator(s.client, &ec2.DescribeSnapshotsInput{
+		DryRun:              nil,
+		Filters:             nil,
+		MaxResults:          nil,
+		NextToken:           nil,
+		OwnerIds:            []string{target},
+		RestorableByUserIds: []string{permissionAll},
+		SnapshotIds:         nil,
+	})
+	for paginator.HasMorePages() {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+		}
+
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch public snapshots, %w", err)
+		}
+
+		for _, snapshot := range page.Snapshots {
+			output[aws.ToString(snapshot.SnapshotId)] = struct{}{}
+		}
+	}
+
+	return output, nil
+}
+
+// exposure returns who can create volumes from the snapshot, based on its createVolumePermission attribute.
+// Snapshots of another account are public when listed in public, and otherwise shared with the caller.
+func (s *EBSSnapshotScan) exposure(
+	ctx context.Context,
+	snapshot *types.Snapshot,
+	target string,
+	public map[string]struct{},
+) (Exposure, []string) {
+	if isExternalScan(ctx, target) {
+		if _, ok := public[aws.ToString(snapshot.SnapshotId)]; ok {
+			return ExposurePublic, nil
+		}
+
+		return externalExposure(ctx)
+	}
+
+	attribute, err := s.client.DescribeSnapshotAttribute(ctx, &ec2.DescribeSnapshotAttributeInput{
+		Attribute:  types.SnapshotAttributeNameCreateVolumePermission,
+		SnapshotId: snapshot.SnapshotId,
+		DryRun:     nil,
+	})
+	if err != nil {
+		slog.Debug("failed to describe EBS snapshot permissions",
+			slog.String("error", err.Error()),
+			slog.String("id", *snapshot.SnapshotId),
+			slog.String("region", s.region),
+		)
+
+		return ExposureUnknown, nil
+	}
+
+	permissions := make([]string, 0, len(attribute.CreateVolumePermissions))
+
+	for _, permission := range attribute.CreateVolumePermissions {
+		switch {
+		case permission.Group == types.PermissionGroupAll:
+			permissions = append(permissions, permissionAll)
+		case permission.UserId != nil:
+			permissions = append(permissions, *permission.UserId)
+		}
+	}
+
+	return exposureFromPermissions(permissions)
+}
+
+// ebsSnapshotDetails returns the triage metadata of an EBS snapshot.
+func ebsSnapshotDetails(snapshot *types.Snapshot) *Details {
+	return newDetails(Details{
+		ARN:               "",
+		ContentNotScanned: false,
+		Description:       aws.ToString(snapshot.Description),
+		Encrypted:         snapshot.Encrypted,
+		KMSKeyID:          aws.ToString(snapshot.KmsKeyId),
+		Name:              "",
+		OwnerAlias:        aws.ToString(snapshot.OwnerAlias),
+		SizeGiB:           int64(aws.ToInt32(snapshot.VolumeSize)),
+	})
+}
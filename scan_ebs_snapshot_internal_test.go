@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -16,18 +17,35 @@ import (
 )
 
 type mockEBSSnapshotClient struct {
-	mockSnapshot    []types.Snapshot
-	mockSnapshotErr error
+	mockSnapshot                []types.Snapshot
+	mockPublicSnapshot          []types.Snapshot
+	mockSnapshotErr             error
+	mockCreateVolumePermissions []types.CreateVolumePermission
+	mockSnapshotAttributeErr    error
 }
 
 func (m *mockEBSSnapshotClient) DescribeSnapshots(
 	_ context.Context,
-	_ *ec2.DescribeSnapshotsInput,
+	params *ec2.DescribeSnapshotsInput,
 	_ ...func(*ec2.Options),
 ) (*ec2.DescribeSnapshotsOutput, error) {
+	if slices.Contains(params.RestorableByUserIds, permissionAll) {
+		return &ec2.DescribeSnapshotsOutput{Snapshots: m.mockPublicSnapshot}, m.mockSnapshotErr
+	}
+
 	return &ec2.DescribeSnapshotsOutput{Snapshots: m.mockSnapshot}, m.mockSnapshotErr
 }
 
+func (m *mockEBSSnapshotClient) DescribeSnapshotAttribute(
+	_ context.Context,
+	_ *ec2.DescribeSnapshotAttributeInput,
+	_ ...func(*ec2.Options),
+) (*ec2.DescribeSnapshotAttributeOutput, error) {
+	return &ec2.DescribeSnapshotAttributeOutput{
+		CreateVolumePermissions: m.mockCreateVolumePermissions,
+	}, m.mockSnapshotAttributeErr
+}
+
 var _ ebsSnapshotClient = (*mockEBSSnapshotClient)(nil)
 
 func Test_ebsSnapshotScan_scan(t *testing.T) {
@@ -97,6 +115,7 @@ func Test_ebsSnapshotScan_scan(t *testing.T) {
 			want: []Result{
 				{
 					CreationDate: now.Format(time.RFC3339),
+					Exposure:     ExposurePrivate,
 					Identifier:   "test-snapshot-id",
 					Region:       "eu-west-1",
 					RType:        SnapshotEBS,
@@ -104,6 +123,84 @@ func Test_ebsSnapshotScan_scan(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should report snapshots of an external account as public or shared with the caller",
+			ctx:  withCaller(t.Context(), "1337"),
+			client: &mockEBSSnapshotClient{
+				mockSnapshot: []types.Snapshot{
+					{
+						CompletionTime: &now,
+						SnapshotId:     aws.String("test-public-id"),
+					},
+					{
+						CompletionTime: &now,
+						SnapshotId:     aws.String("test-shared-id"),
+					},
+				},
+				mockPublicSnapshot: []types.Snapshot{
+					{
+						CompletionTime: &now,
+						SnapshotId:     aws.String("test-public-id"),
+					},
+				},
+				mockSnapshotErr: nil,
+			},
+			region: "eu-west-1",
+			target: "42",
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Exposure:     ExposurePublic,
+					Identifier:   "test-public-id",
+					Region:       "eu-west-1",
+					RType:        SnapshotEBS,
+				},
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Exposure:     ExposureShared,
+					Identifier:   "test-shared-id",
+					Region:       "eu-west-1",
+					RType:        SnapshotEBS,
+					SharedWith:   []string{"1337"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should succeed with snapshot details",
+			ctx:  t.Context(),
+			client: &mockEBSSnapshotClient{
+				mockSnapshot: []types.Snapshot{
+					{
+						CompletionTime: &now,
+						Description:    aws.String("test description"),
+						Encrypted:      aws.Bool(true),
+						KmsKeyId:       aws.String("test-key"),
+						SnapshotId:     aws.String("test-snapshot-id"),
+						VolumeSize:     aws.Int32(42),
+					},
+				},
+				mockSnapshotErr: nil,
+			},
+			region: "eu-west-1",
+			target: "self",
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details: &Details{
+						Description: "test description",
+						Encrypted:   aws.Bool(true),
+						KMSKeyID:    "test-key",
+						SizeGiB:     42,
+					},
+					Exposure:   ExposurePrivate,
+					Identifier: "test-snapshot-id",
+					Region:     "eu-west-1",
+					RType:      SnapshotEBS,
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,125 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecrpublic"
+	"github.com/aws/aws-sdk-go-v2/service/ecrpublic/types"
+)
+
+// ecrPublicRegion is the only region serving the ECR Public API.
+const ecrPublicRegion = "us-east-1"
+
+var (
+	_ ecrpublic.DescribeRepositoriesAPIClient = (ecrPublicRepositoryClient)(nil)
+	_ Runner                                  = (*ECRPublicRepositoryScan)(nil)
+)
+
+type ecrPublicRepositoryClient interface {
+	ecrpublic.DescribeRepositoriesAPIClient
+}
+
+// ECRPublicRepositoryScan scans repositories published in the ECR Public gallery.
+type ECRPublicRepositoryScan struct {
+	baseRunner
+
+	client ecrPublicRepositoryClient
+}
+
+// NewECRPublicRepositoryScan creates a new ECRPublicRepositoryScan with the given config.
+// The ECR Public API is only available in us-east-1, so the config region is replaced.
+func NewECRPublicRepositoryScan(cfg aws.Config) *ECRPublicRepositoryScan {
+	cfg = cfg.Copy()
+	cfg.Region = ecrPublicRegion
+
+	client := ecrpublic.NewFromConfig(cfg)
+
+	return &ECRPublicRepositoryScan{
+		baseRunner: baseRunner{
+			region:     cfg.Region,
+			runnerType: RepositoryECR,
+		},
+		client: client,
+	}
+}
+
+// Scan retrieves ECR Public repositories published by the target AWS account.
+// ECR Public only describes the registry of the caller, so another target is skipped,
+// unless it is scanned with a role assumed in the target account.
+func (s *ECRPublicRepositoryScan) Scan(ctx context.Context, target string) ([]Result, error) {
+	if isExternalScan(ctx, target) {
+		slog.Debug("skipping ECR Public registry of another account",
+			slog.String("caller", callerAccount(ctx)),
+			slog.String("target", target),
+		)
+
+		return nil, nil
+	}
+
+	var output []Result
+
+	paginator := ecrpublic.NewDescribeRepositoriesPaginator(
+		s.client,
+		&ecrpublic.DescribeRepositoriesInput{
+			MaxResults:      nil,
+			NextToken:       nil,
+			RegistryId:      aws.String(target),
+			RepositoryNames: nil,
+		},
+	)
+	for paginator.HasMorePages() {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+		}
+
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch ECR Public repositories, %w", err)
+		}
+
+		for _, repository := range page.Repositories {
+			output = append(output, Result{
+				Account:          "",
+				BackingSnapshots: nil,
+				CreationDate:     repository.CreatedAt.Format(time.RFC3339),
+				Details:          ecrPublicRepositoryDetails(&repository),
+				Engine:           "",
+				EngineVersion:    "",
+				Exposure:         ExposurePublic,
+				FirstSeen:        "",
+				Identifier:       *repository.RepositoryUri,
+				ParentImage:      "",
+				PolicyStatements: nil,
+				Region:           s.region,
+				RType:            s.RunType(),
+				Secrets:          nil,
+				Severity:         "",
+				SharedWith:       nil,
+				Tags:             nil,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+// ecrPublicRepositoryDetails returns the triage metadata of an ECR Public repository.
+func ecrPublicRepositoryDetails(repository *types.Repository) *Details {
+	return newDetails(Details{
+		ARN:               "",
+		ContentNotScanned: false,
+		Description:       "",
+		Encrypted:         nil,
+		KMSKeyID:          "",
+		Name:              aws.ToString(repository.RepositoryName),
+		OwnerAlias:        "",
+		SizeGiB:           0,
+	})
+}
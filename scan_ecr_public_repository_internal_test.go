@@ -0,0 +1,134 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecrpublic"
+	"github.com/aws/aws-sdk-go-v2/service/ecrpublic/types"
+)
+
+type mockECRPublicRepositoryClient struct {
+	mockRepositories            []types.Repository
+	mockDescribeRepositoriesErr error
+}
+
+func (m *mockECRPublicRepositoryClient) DescribeRepositories(
+	_ context.Context,
+	_ *ecrpublic.DescribeRepositoriesInput,
+	_ ...func(*ecrpublic.Options),
+) (*ecrpublic.DescribeRepositoriesOutput, error) {
+	return &ecrpublic.DescribeRepositoriesOutput{
+		Repositories: m.mockRepositories,
+	}, m.mockDescribeRepositoriesErr
+}
+
+var _ ecrPublicRepositoryClient = (*mockECRPublicRepositoryClient)(nil)
+
+func Test_ecrPublicRepositoryScan_scan(t *testing.T) {
+	t.Parallel()
+	withTimeout, cancel := context.WithTimeout(t.Context(), -time.Minute)
+	defer cancel()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context //nolint:containedctx
+		client  ecrPublicRepositoryClient
+		target  string
+		want    []Result
+		wantErr bool
+	}{
+		{
+			name: "should fail when ctx is cancelled",
+			ctx:  withTimeout,
+			client: &mockECRPublicRepositoryClient{
+				mockRepositories:            nil,
+				mockDescribeRepositoriesErr: nil,
+			},
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should fail when api returns error",
+			ctx:  t.Context(),
+			client: &mockECRPublicRepositoryClient{
+				mockRepositories:            nil,
+				mockDescribeRepositoriesErr: errors.New("some error"),
+			},
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should skip the registry of another account",
+			ctx:  withCaller(t.Context(), "1337"),
+			client: &mockECRPublicRepositoryClient{
+				mockRepositories:            nil,
+				mockDescribeRepositoriesErr: errors.New("registry does not belong to the caller"),
+			},
+			target:  "42",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "should succeed with one repository",
+			ctx:  t.Context(),
+			client: &mockECRPublicRepositoryClient{
+				mockRepositories: []types.Repository{
+					{
+						CreatedAt:      &now,
+						RegistryId:     aws.String("42"),
+						RepositoryName: aws.String("tool"),
+						RepositoryUri:  aws.String("public.ecr.aws/example/tool"),
+					},
+				},
+				mockDescribeRepositoriesErr: nil,
+			},
+			target: "42",
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details:      &Details{Name: "tool"},
+					Exposure:     ExposurePublic,
+					Identifier:   "public.ecr.aws/example/tool",
+					Region:       ecrPublicRegion,
+					RType:        RepositoryECR,
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &ECRPublicRepositoryScan{
+				baseRunner: baseRunner{
+					region:     ecrPublicRegion,
+					runnerType: RepositoryECR,
+				},
+				client: tt.client,
+			}
+
+			got, err := s.Scan(tt.ctx, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("scan() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scan() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+var (
+	_ ecr.DescribeRepositoriesAPIClient = (ecrRepositoryClient)(nil)
+	_ Runner                            = (*ECRRepositoryScan)(nil)
+)
+
+type ecrRepositoryClient interface {
+	ecr.DescribeRepositoriesAPIClient
+	GetRepositoryPolicy(
+		ctx context.Context,
+		params *ecr.GetRepositoryPolicyInput,
+		optFns ...func(*ecr.Options),
+	) (*ecr.GetRepositoryPolicyOutput, error)
+}
+
+// ECRRepositoryFilter defines a function for filtering private ECR repositories.
+type ECRRepositoryFilter func(repository *types.Repository, target string) bool
+
+func isECRRepositoryOwner(repository *types.Repository, target string) bool {
+	return repository.RegistryId == nil || *repository.RegistryId != target
+}
+
+// ECRRepositoryScan scans private ECR repositories whose policy allows "*" principals in a region.
+type ECRRepositoryScan struct {
+	baseRunner
+
+	client ecrRepositoryClient
+	filter ECRRepositoryFilter
+}
+
+// NewECRRepositoryScan creates a new ECRRepositoryScan with the given config and filter.
+func NewECRRepositoryScan(cfg aws.Config, filterFunc ECRRepositoryFilter) *ECRRepositoryScan {
+	client := ecr.NewFromConfig(cfg)
+
+	return &ECRRepositoryScan{
+		baseRunner: baseRunner{
+			region:     cfg.Region,
+			runnerType: RepositoryECR,
+		},
+		client: client,
+		filter: filterFunc,
+	}
+}
+
+// Scan retrieves private ECR repositories with a public repository policy.
+// ECR only lists the repositories of the caller, so the target has to be scanned from inside its account.
+func (s *ECRRepositoryScan) Scan(ctx context.Context, target string) ([]Result, error) {
+	var output []Result
+
+	paginator := ecr.NewDescribeRepositoriesPaginator(s.client, &ecr.DescribeRepositoriesInput{
+		MaxResults:      nil,
+		NextToken:       nil,
+		RegistryId:      nil,
+		RepositoryNames: nil,
+	})
+	for paginator.HasMorePages() {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+		}
+
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch ECR repositories, %w", err)
+		}
+
+		for _, repository := range page.Repositories {
+			if s.filter != nil && s.filter(&repository, target) {
+				slog.Debug("skipping ECR repository",
+					slog.String("name", *repository.RepositoryName),
+					slog.String("region", s.region),
+				)
+
+				continue
+			}
+
+			statements, err := s.publicStatements(ctx, &repository, target)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(statements) == 0 {
+				continue
+			}
+
+			output = append(output, Result{
+				Account:          "",
+				BackingSnapshots: nil,
+				CreationDate:     repository.CreatedAt.Format(time.RFC3339),
+				Details:          ecrRepositoryDetails(&repository),
+				Engine:           "",
+				EngineVersion:    "",
+				Exposure:         ExposurePublic,
+				FirstSeen:        "",
+				Identifier:       *repository.RepositoryUri,
+				ParentImage:      "",
+				PolicyStatements: statements,
+				Region:           s.region,
+				RType:            s.RunType(),
+				Secrets:          nil,
+				Severity:         "",
+				SharedWith:       nil,
+				Tags:             nil,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+// publicStatements returns the repository policy statements that allow "*" principals.
+// Statements limited by a condition, e.g. aws:PrincipalOrgID or aws:SourceVpce, are not public.
+func (s *ECRRepositoryScan) publicStatements(
+	ctx context.Context,
+	repository *types.Repository,
+	target string,
+) ([]json.RawMessage, error) {
+	policy, err := s.client.GetRepositoryPolicy(ctx, &ecr.GetRepositoryPolicyInput{
+		RepositoryName: repository.RepositoryName,
+		RegistryId:     repository.RegistryId,
+	})
+	if err != nil {
+		var notFound *types.RepositoryPolicyNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to fetch ECR repository policy, %w", err)
+	}
+
+	grants, err := externalGrants(*policy.PolicyText, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []json.RawMessage
+
+	for _, grant := range grants {
+		if grant.isPublic() {
+			statements = append(statements, grant.Statement)
+		}
+	}
+
+	return statements, nil
+}
+
+// ecrRepositoryDetails returns the triage metadata of a private ECR repository.
+// Repositories are always encrypted at rest, either with AES256 or a KMS key.
+func ecrRepositoryDetails(repository *types.Repository) *Details {
+	var kmsKeyID string
+	if repository.EncryptionConfiguration != nil {
+		kmsKeyID = aws.ToString(repository.EncryptionConfiguration.KmsKey)
+	}
+
+	return newDetails(Details{
+		ARN:               "",
+		ContentNotScanned: false,
+		Description:       "",
+		Encrypted:         aws.Bool(true),
+		KMSKeyID:          kmsKeyID,
+		Name:              aws.ToString(repository.RepositoryName),
+		OwnerAlias:        "",
+		SizeGiB:           0,
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"github.com/aws/aws-sdk-go-v2/service/ecr/types"
+)
+
+type mockECRRepositoryClient struct {
+	mockRepositories            []types.Repository
+	mockDescribeRepositoriesErr error
+	mockPolicies                map[string]string
+	mockGetRepositoryPolicyErr  error
+}
+
+func (m *mockECRRepositoryClient) DescribeRepositories(
+	_ context.Context,
+	_ *ecr.DescribeRepositoriesInput,
+	_ ...func(*ecr.Options),
+) (*ecr.DescribeRepositoriesOutput, error) {
+	return &ecr.DescribeRepositoriesOutput{
+		Repositories: m.mockRepositories,
+	}, m.mockDescribeRepositoriesErr
+}
+
+func (m *mockECRRepositoryClient) GetRepositoryPolicy(
+	_ context.Context,
+	params *ecr.GetRepositoryPolicyInput,
+	_ ...func(*ecr.Options),
+) (*ecr.GetRepositoryPolicyOutput, error) {
+	if m.mockGetRepositoryPolicyErr != nil {
+		return nil, m.mockGetRepositoryPolicyErr
+	}
+
+	policy, ok := m.mockPolicies[*params.RepositoryName]
+	if !ok {
+		return nil, &types.RepositoryPolicyNotFoundException{}
+	}
+
+	return &ecr.GetRepositoryPolicyOutput{PolicyText: aws.String(policy)}, nil
+}
+
+var _ ecrRepositoryClient = (*mockECRRepositoryClient)(nil)
+
+func Test_ecrRepositoryScan_scan(t *testing.T) {
+	t.Parallel()
+	withTimeout, cancel := context.WithTimeout(t.Context(), -time.Minute)
+	defer cancel()
+	now := time.Now()
+
+	publicStatement := `{"Effect":"Allow","Principal":"*","Action":"ecr:BatchGetImage"}`
+	repositories := []types.Repository{
+		{
+			CreatedAt:      &now,
+			RegistryId:     aws.String("42"),
+			RepositoryName: aws.String("public"),
+			RepositoryUri:  aws.String("42.dkr.ecr.eu-west-1.amazonaws.com/public"),
+		},
+		{
+			CreatedAt:      &now,
+			RegistryId:     aws.String("42"),
+			RepositoryName: aws.String("shared"),
+			RepositoryUri:  aws.String("42.dkr.ecr.eu-west-1.amazonaws.com/shared"),
+		},
+		{
+			CreatedAt:      &now,
+			RegistryId:     aws.String("42"),
+			RepositoryName: aws.String("private"),
+			RepositoryUri:  aws.String("42.dkr.ecr.eu-west-1.amazonaws.com/private"),
+		},
+		{
+			CreatedAt:      &now,
+			RegistryId:     aws.String("1337"),
+			RepositoryName: aws.String("public"),
+			RepositoryUri:  aws.String("1337.dkr.ecr.eu-west-1.amazonaws.com/public"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context //nolint:containedctx
+		client  ecrRepositoryClient
+		region  string
+		target  string
+		want    []Result
+		wantErr bool
+	}{
+		{
+			name: "should fail when ctx is cancelled",
+			ctx:  withTimeout,
+			client: &mockECRRepositoryClient{
+				mockRepositories:            nil,
+				mockDescribeRepositoriesErr: nil,
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should fail when api returns error",
+			ctx:  t.Context(),
+			client: &mockECRRepositoryClient{
+				mockRepositories:            nil,
+				mockDescribeRepositoriesErr: errors.New("some error"),
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should fail when policy api returns error",
+			ctx:  t.Context(),
+			client: &mockECRRepositoryClient{
+				mockRepositories:           repositories,
+				mockGetRepositoryPolicyErr: errors.New("some error"),
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should succeed with one public repository",
+			ctx:  t.Context(),
+			client: &mockECRRepositoryClient{
+				mockRepositories: repositories,
+				mockPolicies: map[string]string{
+					"public": `{"Statement":[` + publicStatement + `]}`,
+					"shared": `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"1337"}}]}`,
+				},
+			},
+			region: "eu-west-1",
+			target: "42",
+			want: []Result{
+				{
+					CreationDate:     now.Format(time.RFC3339),
+					Details:          &Details{Encrypted: aws.Bool(true), Name: "public"},
+					Exposure:         ExposurePublic,
+					Identifier:       "42.dkr.ecr.eu-west-1.amazonaws.com/public",
+					PolicyStatements: []json.RawMessage{json.RawMessage(publicStatement)},
+					Region:           "eu-west-1",
+					RType:            RepositoryECR,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should skip repositories limited to an organization or a VPC endpoint",
+			ctx:  t.Context(),
+			client: &mockECRRepositoryClient{
+				mockRepositories: repositories,
+				mockPolicies: map[string]string{
+					"public": `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"ecr:BatchGetImage",
+						"Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"}}}]}`,
+					"shared": `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"ecr:*",
+						"Condition":{"StringEquals":{"aws:SourceVpce":["vpce-1a2b3c4d"]}}}]}`,
+				},
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &ECRRepositoryScan{
+				baseRunner: baseRunner{
+					region:     tt.region,
+					runnerType: RepositoryECR,
+				},
+				client: tt.client,
+				filter: isECRRepositoryOwner,
+			}
+
+			got, err := s.Scan(tt.ctx, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("scan() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scan() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
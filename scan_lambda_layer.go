@@ -0,0 +1,228 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+var (
+	_ lambda.ListLayerVersionsAPIClient = (lambdaLayerClient)(nil)
+	_ lambda.ListLayersAPIClient        = (lambdaLayerClient)(nil)
+	_ Runner                            = (*LambdaLayerScan)(nil)
+)
+
+type lambdaLayerClient interface {
+	lambda.ListLayersAPIClient
+	lambda.ListLayerVersionsAPIClient
+	GetLayerVersionPolicy(
+		ctx context.Context,
+		params *lambda.GetLayerVersionPolicyInput,
+		optFns ...func(*lambda.Options),
+	) (*lambda.GetLayerVersionPolicyOutput, error)
+}
+
+// LambdaLayerFilter defines a function for filtering Lambda layers.
+type LambdaLayerFilter func(layer *types.LayersListItem, target string) bool
+
+func isLambdaLayerOwner(layer *types.LayersListItem, target string) bool {
+	parsed, err := arn.Parse(*layer.LayerArn)
+	if err != nil {
+		return true
+	}
+
+	return parsed.AccountID != target
+}
+
+// LambdaLayerScan scans Lambda layer versions shared outside the owner account in a region.
+type LambdaLayerScan struct {
+	baseRunner
+
+	client lambdaLayerClient
+	filter LambdaLayerFilter
+}
+
+// NewLambdaLayerScan creates a new LambdaLayerScan with the given config and filter.
+func NewLambdaLayerScan(cfg aws.Config, filterFunc LambdaLayerFilter) *LambdaLayerScan {
+	client := lambda.NewFromConfig(cfg)
+
+	return &LambdaLayerScan{
+		baseRunner: baseRunner{
+			region:     cfg.Region,
+			runnerType: LayerLambda,
+		},
+		client: client,
+		filter: filterFunc,
+	}
+}
+
+// Scan retrieves Lambda layer versions whose policy grants access to "*" or to other accounts.
+// Lambda only lists the layers of the caller, so the target has to be scanned from inside its account.
+func (s *LambdaLayerScan) Scan(ctx context.Context, target string) ([]Result, error) {
+	var output []Result
+
+	paginator := lambda.NewListLayersPaginator(s.client, &lambda.ListLayersInput{
+		CompatibleArchitecture: "",
+		CompatibleRuntime:      "",
+		Marker:                 nil,
+		MaxItems:               nil,
+	})
+	for paginator.HasMorePages() {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+		}
+
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch Lambda layer(s), %w", err)
+		}
+
+		for _, layer := range page.Layers {
+			if s.filter != nil && s.filter(&layer, target) {
+				slog.Debug("skipping Lambda layer",
+					slog.String("name", *layer.LayerName),
+					slog.String("region", s.region),
+				)
+
+				continue
+			}
+
+			versions, err := s.scanVersions(ctx, &layer, target)
+			if err != nil {
+				return nil, err
+			}
+
+			output = append(output, versions...)
+		}
+	}
+
+	return output, nil
+}
+
+// scanVersions returns the versions of a layer that are shared outside the target account.
+func (s *LambdaLayerScan) scanVersions(
+	ctx context.Context,
+	layer *types.LayersListItem,
+	target string,
+) ([]Result, error) {
+	var output []Result
+
+	paginator := lambda.NewListLayerVersionsPaginator(s.client, &lambda.ListLayerVersionsInput{
+		LayerName:              layer.LayerName,
+		CompatibleArchitecture: "",
+		CompatibleRuntime:      "",
+		Marker:                 nil,
+		MaxItems:               nil,
+	})
+	for paginator.HasMorePages() {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+		}
+
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch Lambda layer versions, %w", err)
+		}
+
+		for _, version := range page.LayerVersions {
+			grants, err := s.externalGrants(ctx, layer.LayerName, version.Version, target)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(grants) == 0 {
+				continue
+			}
+
+			exposure, sharedWith := lambdaLayerExposure(grants)
+
+			output = append(output, Result{
+				Account:          "",
+				BackingSnapshots: nil,
+				CreationDate:     *version.CreatedDate,
+				Details:          lambdaLayerDetails(layer, &version),
+				Engine:           "",
+				EngineVersion:    "",
+				Exposure:         exposure,
+				FirstSeen:        "",
+				Identifier:       *version.LayerVersionArn,
+				ParentImage:      "",
+				PolicyStatements: nil,
+				Region:           s.region,
+				RType:            s.RunType(),
+				Secrets:          nil,
+				Severity:         "",
+				SharedWith:       sharedWith,
+				Tags:             nil,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+// externalGrants returns the layer version policy grants to "*" or to accounts other than the target.
+func (s *LambdaLayerScan) externalGrants(
+	ctx context.Context,
+	name *string,
+	version int64,
+	target string,
+) ([]policyGrant, error) {
+	policy, err := s.client.GetLayerVersionPolicy(ctx, &lambda.GetLayerVersionPolicyInput{
+		LayerName:     name,
+		VersionNumber: aws.Int64(version),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to fetch Lambda layer version policy, %w", err)
+	}
+
+	return externalGrants(*policy.Policy, target)
+}
+
+// lambdaLayerExposure returns who can use the layer version, based on its policy grants.
+func lambdaLayerExposure(grants []policyGrant) (Exposure, []string) {
+	var permissions []string
+
+	for _, grant := range grants {
+		for _, principal := range grant.Principals {
+			if principal == policyWildcard {
+				principal = permissionAll
+			}
+
+			permissions = append(permissions, principal)
+		}
+	}
+
+	return exposureFromPermissions(permissions)
+}
+
+// lambdaLayerDetails returns the triage metadata of a Lambda layer version.
+func lambdaLayerDetails(
+	layer *types.LayersListItem,
+	version *types.LayerVersionsListItem,
+) *Details {
+	return newDetails(Details{
+		ARN:               "",
+		ContentNotScanned: false,
+		Description:       aws.ToString(version.Description),
+		Encrypted:         nil,
+		KMSKeyID:          "",
+		Name:              aws.ToString(layer.LayerName),
+		OwnerAlias:        "",
+		SizeGiB:           0,
+	})
+}
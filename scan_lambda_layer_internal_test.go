@@ -0,0 +1,245 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+type mockLambdaLayerClient struct {
+	mockLayers                   []types.LayersListItem
+	mockListLayersErr            error
+	mockLayerVersions            []types.LayerVersionsListItem
+	mockListLayerVersionsErr     error
+	mockPolicies                 map[int64]string
+	mockGetLayerVersionPolicyErr error
+}
+
+func (m *mockLambdaLayerClient) ListLayers(
+	_ context.Context,
+	_ *lambda.ListLayersInput,
+	_ ...func(*lambda.Options),
+) (*lambda.ListLayersOutput, error) {
+	return &lambda.ListLayersOutput{Layers: m.mockLayers}, m.mockListLayersErr
+}
+
+func (m *mockLambdaLayerClient) ListLayerVersions(
+	_ context.Context,
+	_ *lambda.ListLayerVersionsInput,
+	_ ...func(*lambda.Options),
+) (*lambda.ListLayerVersionsOutput, error) {
+	return &lambda.ListLayerVersionsOutput{
+		LayerVersions: m.mockLayerVersions,
+	}, m.mockListLayerVersionsErr
+}
+
+func (m *mockLambdaLayerClient) GetLayerVersionPolicy(
+	_ context.Context,
+	params *lambda.GetLayerVersionPolicyInput,
+	_ ...func(*lambda.Options),
+) (*lambda.GetLayerVersionPolicyOutput, error) {
+	if m.mockGetLayerVersionPolicyErr != nil {
+		return nil, m.mockGetLayerVersionPolicyErr
+	}
+
+	policy, ok := m.mockPolicies[*params.VersionNumber]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{}
+	}
+
+	return &lambda.GetLayerVersionPolicyOutput{Policy: aws.String(policy)}, nil
+}
+
+var _ lambdaLayerClient = (*mockLambdaLayerClient)(nil)
+
+func Test_lambdaLayerScan_scan(t *testing.T) {
+	t.Parallel()
+	withTimeout, cancel := context.WithTimeout(t.Context(), -time.Minute)
+	defer cancel()
+
+	layers := []types.LayersListItem{
+		{
+			LayerArn:  aws.String("arn:aws:lambda:eu-west-1:42:layer:shared"),
+			LayerName: aws.String("shared"),
+		},
+		{
+			LayerArn:  aws.String("arn:aws:lambda:eu-west-1:1337:layer:skip"),
+			LayerName: aws.String("skip"),
+		},
+	}
+	versions := []types.LayerVersionsListItem{
+		{
+			CreatedDate:     aws.String("2025-01-01T00:00:00.000+0000"),
+			LayerVersionArn: aws.String("arn:aws:lambda:eu-west-1:42:layer:shared:1"),
+			Version:         1,
+		},
+		{
+			CreatedDate:     aws.String("2025-01-02T00:00:00.000+0000"),
+			LayerVersionArn: aws.String("arn:aws:lambda:eu-west-1:42:layer:shared:2"),
+			Version:         2,
+		},
+		{
+			CreatedDate:     aws.String("2025-01-03T00:00:00.000+0000"),
+			LayerVersionArn: aws.String("arn:aws:lambda:eu-west-1:42:layer:shared:3"),
+			Version:         3,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context //nolint:containedctx
+		client  lambdaLayerClient
+		region  string
+		target  string
+		want    []Result
+		wantErr bool
+	}{
+		{
+			name: "should fail when ctx is cancelled",
+			ctx:  withTimeout,
+			client: &mockLambdaLayerClient{
+				mockLayers:        nil,
+				mockListLayersErr: nil,
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should fail when api returns error",
+			ctx:  t.Context(),
+			client: &mockLambdaLayerClient{
+				mockLayers:        nil,
+				mockListLayersErr: errors.New("some error"),
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should fail when policy api returns error",
+			ctx:  t.Context(),
+			client: &mockLambdaLayerClient{
+				mockLayers:                   layers,
+				mockLayerVersions:            versions,
+				mockGetLayerVersionPolicyErr: errors.New("some error"),
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should succeed with zero layers when no layers found",
+			ctx:  t.Context(),
+			client: &mockLambdaLayerClient{
+				mockLayers:        nil,
+				mockListLayersErr: nil,
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "should succeed with public and shared layer versions",
+			ctx:  t.Context(),
+			client: &mockLambdaLayerClient{
+				mockLayers:        layers,
+				mockLayerVersions: versions,
+				mockPolicies: map[int64]string{
+					1: `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"lambda:GetLayerVersion"}]}`,
+					2: `{"Statement":{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::42:root"}}}`,
+					3: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":["1337"]}}]}`,
+				},
+			},
+			region: "eu-west-1",
+			target: "42",
+			want: []Result{
+				{
+					CreationDate: "2025-01-01T00:00:00.000+0000",
+					Details:      &Details{Name: "shared"},
+					Exposure:     ExposurePublic,
+					Identifier:   "arn:aws:lambda:eu-west-1:42:layer:shared:1",
+					Region:       "eu-west-1",
+					RType:        LayerLambda,
+				},
+				{
+					CreationDate: "2025-01-03T00:00:00.000+0000",
+					Details:      &Details{Name: "shared"},
+					Exposure:     ExposureShared,
+					Identifier:   "arn:aws:lambda:eu-west-1:42:layer:shared:3",
+					Region:       "eu-west-1",
+					RType:        LayerLambda,
+					SharedWith:   []string{"1337"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should report layer versions shared with an organization as shared",
+			ctx:  t.Context(),
+			client: &mockLambdaLayerClient{
+				mockLayers:        layers,
+				mockLayerVersions: versions,
+				mockPolicies: map[int64]string{
+					1: `{"Version":"2012-10-17","Id":"default","Statement":[{
+						"Sid":"org","Effect":"Allow","Principal":"*","Action":"lambda:GetLayerVersion",
+						"Resource":"arn:aws:lambda:eu-west-1:42:layer:shared:1",
+						"Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"}}
+					}]}`,
+				},
+			},
+			region: "eu-west-1",
+			target: "42",
+			want: []Result{
+				{
+					CreationDate: "2025-01-01T00:00:00.000+0000",
+					Details:      &Details{Name: "shared"},
+					Exposure:     ExposureShared,
+					Identifier:   "arn:aws:lambda:eu-west-1:42:layer:shared:1",
+					Region:       "eu-west-1",
+					RType:        LayerLambda,
+					SharedWith:   []string{"o-a1b2c3d4e5"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &LambdaLayerScan{
+				baseRunner: baseRunner{
+					region:     tt.region,
+					runnerType: LayerLambda,
+				},
+				client: tt.client,
+				filter: isLambdaLayerOwner,
+			}
+
+			got, err := s.Scan(tt.ctx, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("scan() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scan() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,10 +7,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
@@ -22,13 +22,23 @@ var (
 
 type rdsClusterSnapshotClient interface {
 	rds.DescribeDBClusterSnapshotsAPIClient
+	DescribeDBClusterSnapshotAttributes(
+		ctx context.Context,
+		params *rds.DescribeDBClusterSnapshotAttributesInput,
+		optFns ...func(*rds.Options),
+	) (*rds.DescribeDBClusterSnapshotAttributesOutput, error)
 }
 
-// RdsClusterSnapshotFilter defines a function for filtering RDS cluster snapshots.
-type RdsClusterSnapshotFilter func(snapshot *types.DBClusterSnapshot, target string) bool
+// RdsClusterSnapshotFilter defines a function for filtering RDS cluster snapshots,
+// snapshotARN is the parsed DBClusterSnapshotArn.
+type RdsClusterSnapshotFilter func(snapshot *types.DBClusterSnapshot, snapshotARN arn.ARN, target string) bool
 
-func isRDSClusterSnapshotOwner(snapshot *types.DBClusterSnapshot, target string) bool {
-	return !strings.Contains(":"+*snapshot.DBClusterSnapshotIdentifier+":", target)
+func isRDSClusterSnapshotOwner(
+	_ *types.DBClusterSnapshot,
+	snapshotARN arn.ARN,
+	target string,
+) bool {
+	return snapshotARN.AccountID != target
 }
 
 // RDSClusterSnapshotScan scans RDS cluster snapshots in a region using an RDS client and filter.
@@ -40,23 +50,81 @@ type RDSClusterSnapshotScan struct {
 }
 
 // NewRDSClusterSnapshotRunner creates a new RDSClusterSnapshotScan with the given config and filter.
+// It reports cluster snapshots of every engine except DocumentDB and Neptune.
 func NewRDSClusterSnapshotRunner(
 	cfg aws.Config,
 	filterFunc RdsClusterSnapshotFilter,
+) *RDSClusterSnapshotScan {
+	return newClusterSnapshotRunner(cfg, SnapshotRDS, filterFunc)
+}
+
+// NewDocDBClusterSnapshotRunner creates a new RDSClusterSnapshotScan for DocumentDB cluster snapshots.
+func NewDocDBClusterSnapshotRunner(
+	cfg aws.Config,
+	filterFunc RdsClusterSnapshotFilter,
+) *RDSClusterSnapshotScan {
+	return newClusterSnapshotRunner(cfg, SnapshotDocDB, filterFunc)
+}
+
+// NewNeptuneClusterSnapshotRunner creates a new RDSClusterSnapshotScan for Neptune cluster snapshots.
+func NewNeptuneClusterSnapshotRunner(
+	cfg aws.Config,
+	filterFunc RdsClusterSnapshotFilter,
+) *RDSClusterSnapshotScan {
+	return newClusterSnapshotRunner(cfg, SnapshotNeptune, filterFunc)
+}
+
+func newClusterSnapshotRunner(
+	cfg aws.Config,
+	runnerType RunnerType,
+	filterFunc RdsClusterSnapshotFilter,
 ) *RDSClusterSnapshotScan {
 	client := rds.NewFromConfig(cfg)
 
 	return &RDSClusterSnapshotScan{
 		baseRunner: baseRunner{
 			region:     cfg.Region,
-			runnerType: SnapshotRDS,
+			runnerType: runnerType,
 		},
 		client: client,
 		filter: filterFunc,
 	}
 }
 
-// Scan retrieves RDS cluster snapshots for the target AWS account.
+// clusterEngineRunnerType returns the RunnerType responsible for cluster snapshots of the given engine.
+func clusterEngineRunnerType(engine string) RunnerType {
+	switch engine {
+	case "docdb":
+		return SnapshotDocDB
+	case "neptune":
+		return SnapshotNeptune
+	default:
+		return SnapshotRDS
+	}
+}
+
+// clusterEngineFilters returns the API filters that limit the results to the engine of the given RunnerType.
+func clusterEngineFilters(runnerType RunnerType) []types.Filter {
+	var engine string
+
+	switch runnerType { //nolint:exhaustive
+	case SnapshotDocDB:
+		engine = "docdb"
+	case SnapshotNeptune:
+		engine = "neptune"
+	default:
+		return nil
+	}
+
+	return []types.Filter{
+		{
+			Name:   aws.String("engine"),
+			Values: []string{engine},
+		},
+	}
+}
+
+// Scan retrieves cluster snapshots of the runner's engine for the target AWS account.
 func (r *RDSClusterSnapshotScan) Scan(ctx context.Context, target string) ([]Result, error) {
 	var output []Result
 
@@ -66,7 +134,7 @@ func (r *RDSClusterSnapshotScan) Scan(ctx context.Context, target string) ([]Res
 			DBClusterIdentifier:         nil,
 			DBClusterSnapshotIdentifier: nil,
 			DbClusterResourceId:         nil,
-			Filters:                     nil,
+			Filters:                     clusterEngineFilters(r.RunType()),
 			IncludePublic:               aws.Bool(true),
 			IncludeShared:               aws.Bool(true),
 			Marker:                      nil,
@@ -85,7 +153,22 @@ func (r *RDSClusterSnapshotScan) Scan(ctx context.Context, target string) ([]Res
 		}
 
 		for _, snapshot := range page.DBClusterSnapshots {
-			if r.filter != nil && r.filter(&snapshot, target) {
+			if clusterEngineRunnerType(aws.ToString(snapshot.Engine)) != r.RunType() {
+				continue
+			}
+
+			snapshotARN, err := parseRDSARN(snapshot.DBClusterSnapshotArn)
+			if err != nil {
+				slog.Debug("skipping RDS cluster snapshot with invalid ARN",
+					slog.String("error", err.Error()),
+					slog.String("name", *snapshot.DBClusterSnapshotIdentifier),
+					slog.String("region", r.region),
+				)
+
+				continue
+			}
+
+			if r.filter != nil && r.filter(&snapshot, snapshotARN, target) {
 				slog.Debug("skipping RDS cluster snapshots",
 					slog.String("name", *snapshot.DBClusterSnapshotIdentifier),
 					slog.String("region", r.region),
@@ -94,14 +177,87 @@ func (r *RDSClusterSnapshotScan) Scan(ctx context.Context, target string) ([]Res
 				continue
 			}
 
+			exposure, sharedWith := r.exposure(ctx, &snapshot, target)
+
 			output = append(output, Result{
-				CreationDate: snapshot.SnapshotCreateTime.Format(time.RFC3339),
-				Identifier:   *snapshot.DBClusterSnapshotIdentifier,
-				Region:       r.region,
-				RType:        r.RunType(),
+				Account:          "",
+				BackingSnapshots: nil,
+				CreationDate:     snapshot.SnapshotCreateTime.Format(time.RFC3339),
+				Details:          rdsClusterSnapshotDetails(&snapshot),
+				Engine:           aws.ToString(snapshot.Engine),
+				EngineVersion:    aws.ToString(snapshot.EngineVersion),
+				Exposure:         exposure,
+				FirstSeen:        "",
+				Identifier:       *snapshot.DBClusterSnapshotIdentifier,
+				ParentImage:      "",
+				PolicyStatements: nil,
+				Region:           r.region,
+				RType:            r.RunType(),
+				Secrets:          nil,
+				Severity:         "",
+				SharedWith:       sharedWith,
+				Tags:             tagsToMap(snapshot.TagList, rdsTag),
 			})
 		}
 	}
 
 	return output, nil
 }
+
+// exposure returns who can restore the cluster snapshot, based on its type or its restore attribute.
+func (r *RDSClusterSnapshotScan) exposure(
+	ctx context.Context,
+	snapshot *types.DBClusterSnapshot,
+	target string,
+) (Exposure, []string) {
+	switch aws.ToString(snapshot.SnapshotType) {
+	case rdsSnapshotTypePublic:
+		return ExposurePublic, nil
+	case rdsSnapshotTypeShared:
+		return externalExposure(ctx)
+	}
+
+	if isExternalScan(ctx, target) {
+		return externalExposure(ctx)
+	}
+
+	attributes, err := r.client.DescribeDBClusterSnapshotAttributes(
+		ctx,
+		&rds.DescribeDBClusterSnapshotAttributesInput{
+			DBClusterSnapshotIdentifier: snapshot.DBClusterSnapshotIdentifier,
+		},
+	)
+	if err != nil || attributes.DBClusterSnapshotAttributesResult == nil {
+		slog.Debug("failed to describe RDS cluster snapshot attributes",
+			slog.Any("error", err),
+			slog.String("name", *snapshot.DBClusterSnapshotIdentifier),
+			slog.String("region", r.region),
+		)
+
+		return ExposureUnknown, nil
+	}
+
+	var permissions []string
+
+	for _, attribute := range attributes.DBClusterSnapshotAttributesResult.DBClusterSnapshotAttributes {
+		if aws.ToString(attribute.AttributeName) == rdsRestoreAttribute {
+			permissions = attribute.AttributeValues
+		}
+	}
+
+	return exposureFromPermissions(permissions)
+}
+
+// rdsClusterSnapshotDetails returns the triage metadata of a cluster snapshot, named after its source cluster.
+func rdsClusterSnapshotDetails(snapshot *types.DBClusterSnapshot) *Details {
+	return newDetails(Details{
+		ARN:               aws.ToString(snapshot.DBClusterSnapshotArn),
+		ContentNotScanned: false,
+		Description:       "",
+		Encrypted:         snapshot.StorageEncrypted,
+		KMSKeyID:          aws.ToString(snapshot.KmsKeyId),
+		Name:              aws.ToString(snapshot.DBClusterIdentifier),
+		OwnerAlias:        "",
+		SizeGiB:           int64(aws.ToInt32(snapshot.AllocatedStorage)),
+	})
+}
@@ -16,8 +16,10 @@ import (
 )
 
 type mockRDSClusterSnapshotClient struct {
-	mockDBClusterSnapshot    []types.DBClusterSnapshot
-	mockDBClusterSnapshotErr error
+	mockDBClusterSnapshot             []types.DBClusterSnapshot
+	mockDBClusterSnapshotErr          error
+	mockRestoreAccounts               []string
+	mockDBClusterSnapshotAttributeErr error
 }
 
 func (m *mockRDSClusterSnapshotClient) DescribeDBClusterSnapshots(
@@ -30,6 +32,24 @@ func (m *mockRDSClusterSnapshotClient) DescribeDBClusterSnapshots(
 	}, m.mockDBClusterSnapshotErr
 }
 
+func (m *mockRDSClusterSnapshotClient) DescribeDBClusterSnapshotAttributes(
+	_ context.Context,
+	_ *rds.DescribeDBClusterSnapshotAttributesInput,
+	_ ...func(*rds.Options),
+) (*rds.DescribeDBClusterSnapshotAttributesOutput, error) {
+	return &rds.DescribeDBClusterSnapshotAttributesOutput{
+		DBClusterSnapshotAttributesResult: &types.DBClusterSnapshotAttributesResult{
+			DBClusterSnapshotAttributes: []types.DBClusterSnapshotAttribute{
+				{
+					AttributeName:   aws.String(rdsRestoreAttribute),
+					AttributeValues: m.mockRestoreAccounts,
+				},
+			},
+			DBClusterSnapshotIdentifier: nil,
+		},
+	}, m.mockDBClusterSnapshotAttributeErr
+}
+
 var _ rdsClusterSnapshotClient = (*mockRDSClusterSnapshotClient)(nil)
 
 func Test_rdsClusterSnapshotScan_scan(t *testing.T) {
@@ -88,24 +108,39 @@ func Test_rdsClusterSnapshotScan_scan(t *testing.T) {
 			client: &mockRDSClusterSnapshotClient{
 				mockDBClusterSnapshot: []types.DBClusterSnapshot{
 					{
-						SnapshotCreateTime:          &now,
+						SnapshotCreateTime: &now,
+						DBClusterSnapshotArn: aws.String(
+							"arn:aws:rds:eu-west-1:123456789012:cluster-snapshot:test-self-id",
+						),
 						DBClusterSnapshotIdentifier: aws.String("test-self-id"),
 					},
+					{
+						SnapshotCreateTime: &now,
+						DBClusterSnapshotArn: aws.String(
+							"arn:aws:rds:eu-west-1:210987654321:cluster-snapshot:test-123456789012",
+						),
+						DBClusterSnapshotIdentifier: aws.String("test-123456789012"),
+					},
 					{
 						SnapshotCreateTime:          &now,
-						DBClusterSnapshotIdentifier: aws.String("test-skip-id"),
+						DBClusterSnapshotArn:        nil,
+						DBClusterSnapshotIdentifier: aws.String("test-invalid-arn-id"),
 					},
 				},
 				mockDBClusterSnapshotErr: nil,
 			},
 			region: "eu-west-1",
-			target: "self",
+			target: "123456789012",
 			want: []Result{
 				{
 					CreationDate: now.Format(time.RFC3339),
-					Identifier:   "test-self-id",
-					Region:       "eu-west-1",
-					RType:        SnapshotRDS,
+					Details: &Details{
+						ARN: "arn:aws:rds:eu-west-1:123456789012:cluster-snapshot:test-self-id",
+					},
+					Exposure:   ExposurePrivate,
+					Identifier: "test-self-id",
+					Region:     "eu-west-1",
+					RType:      SnapshotRDS,
 				},
 			},
 			wantErr: false,
@@ -137,3 +172,125 @@ func Test_rdsClusterSnapshotScan_scan(t *testing.T) {
 		})
 	}
 }
+
+func Test_rdsClusterSnapshotScan_engines(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	client := &mockRDSClusterSnapshotClient{
+		mockDBClusterSnapshot: []types.DBClusterSnapshot{
+			{
+				DBClusterSnapshotArn: aws.String(
+					"arn:aws:rds:eu-west-1:42:cluster-snapshot:aurora-self",
+				),
+				DBClusterSnapshotIdentifier: aws.String("aurora-self"),
+				Engine:                      aws.String("aurora-postgresql"),
+				EngineVersion:               aws.String("16.4"),
+				SnapshotCreateTime:          &now,
+			},
+			{
+				DBClusterSnapshotArn: aws.String(
+					"arn:aws:rds:eu-west-1:42:cluster-snapshot:docdb-self",
+				),
+				DBClusterSnapshotIdentifier: aws.String("docdb-self"),
+				Engine:                      aws.String("docdb"),
+				EngineVersion:               aws.String("5.0.0"),
+				SnapshotCreateTime:          &now,
+			},
+			{
+				DBClusterSnapshotArn: aws.String(
+					"arn:aws:rds:eu-west-1:42:cluster-snapshot:neptune-self",
+				),
+				DBClusterSnapshotIdentifier: aws.String("neptune-self"),
+				Engine:                      aws.String("neptune"),
+				EngineVersion:               aws.String("1.3.2.1"),
+				SnapshotCreateTime:          &now,
+			},
+		},
+		mockDBClusterSnapshotErr: nil,
+	}
+
+	tests := []struct {
+		name       string
+		runnerType RunnerType
+		want       []Result
+	}{
+		{
+			name:       "RDS skips DocumentDB and Neptune",
+			runnerType: SnapshotRDS,
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details: &Details{
+						ARN: "arn:aws:rds:eu-west-1:42:cluster-snapshot:aurora-self",
+					},
+					Engine:        "aurora-postgresql",
+					EngineVersion: "16.4",
+					Exposure:      ExposurePrivate,
+					Identifier:    "aurora-self",
+					Region:        "eu-west-1",
+					RType:         SnapshotRDS,
+				},
+			},
+		},
+		{
+			name:       "DocumentDB only",
+			runnerType: SnapshotDocDB,
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details: &Details{
+						ARN: "arn:aws:rds:eu-west-1:42:cluster-snapshot:docdb-self",
+					},
+					Engine:        "docdb",
+					EngineVersion: "5.0.0",
+					Exposure:      ExposurePrivate,
+					Identifier:    "docdb-self",
+					Region:        "eu-west-1",
+					RType:         SnapshotDocDB,
+				},
+			},
+		},
+		{
+			name:       "Neptune only",
+			runnerType: SnapshotNeptune,
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details: &Details{
+						ARN: "arn:aws:rds:eu-west-1:42:cluster-snapshot:neptune-self",
+					},
+					Engine:        "neptune",
+					EngineVersion: "1.3.2.1",
+					Exposure:      ExposurePrivate,
+					Identifier:    "neptune-self",
+					Region:        "eu-west-1",
+					RType:         SnapshotNeptune,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &RDSClusterSnapshotScan{
+				baseRunner: baseRunner{
+					region:     "eu-west-1",
+					runnerType: tt.runnerType,
+				},
+				client: client,
+				filter: isRDSClusterSnapshotOwner,
+			}
+
+			got, err := r.Scan(t.Context(), "42")
+			if err != nil {
+				t.Fatalf("scan() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scan() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
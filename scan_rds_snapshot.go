@@ -7,10 +7,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
@@ -22,13 +22,41 @@ var (
 
 type rdsSnapshotClient interface {
 	rds.DescribeDBSnapshotsAPIClient
+	DescribeDBSnapshotAttributes(
+		ctx context.Context,
+		params *rds.DescribeDBSnapshotAttributesInput,
+		optFns ...func(*rds.Options),
+	) (*rds.DescribeDBSnapshotAttributesOutput, error)
 }
 
-// RdsSnapshotFilter defines a function for filtering RDS snapshots.
-type RdsSnapshotFilter func(snapshot *types.DBSnapshot, target string) bool
+// RdsSnapshotFilter defines a function for filtering RDS snapshots, snapshotARN is the parsed DBSnapshotArn.
+type RdsSnapshotFilter func(snapshot *types.DBSnapshot, snapshotARN arn.ARN, target string) bool
 
-func isRDSSnapshotOwner(snapshot *types.DBSnapshot, target string) bool {
-	return !strings.Contains(":"+*snapshot.DBSnapshotIdentifier+":", target)
+const (
+	// rdsRestoreAttribute is the snapshot attribute listing the accounts allowed to restore it.
+	rdsRestoreAttribute = "restore"
+	// rdsSnapshotTypePublic is the type of snapshots restorable by every account.
+	rdsSnapshotTypePublic = "public"
+	// rdsSnapshotTypeShared is the type of snapshots shared with the caller by another account.
+	rdsSnapshotTypeShared = "shared"
+)
+
+func isRDSSnapshotOwner(_ *types.DBSnapshot, snapshotARN arn.ARN, target string) bool {
+	return snapshotARN.AccountID != target
+}
+
+// parseRDSARN parses the ARN of an RDS resource, so ownership can be read from its account field.
+func parseRDSARN(value *string) (arn.ARN, error) {
+	parsed, err := arn.Parse(aws.ToString(value))
+	if err != nil {
+		return arn.ARN{}, fmt.Errorf(
+			"failed to parse ARN %q, %w",
+			aws.ToString(value),
+			err,
+		) //nolint:exhaustruct
+	}
+
+	return parsed, nil
 }
 
 // RDSSnapshotScan scans RDS snapshots in a region using an RDS client and filter.
@@ -79,7 +107,18 @@ func (r *RDSSnapshotScan) Scan(ctx context.Context, target string) ([]Result, er
 		}
 
 		for _, snapshot := range page.DBSnapshots {
-			if r.filter != nil && r.filter(&snapshot, target) {
+			snapshotARN, err := parseRDSARN(snapshot.DBSnapshotArn)
+			if err != nil {
+				slog.Debug("skipping RDS snapshot with invalid ARN",
+					slog.String("error", err.Error()),
+					slog.String("name", *snapshot.DBSnapshotIdentifier),
+					slog.String("region", r.region),
+				)
+
+				continue
+			}
+
+			if r.filter != nil && r.filter(&snapshot, snapshotARN, target) {
 				slog.Debug("skipping RDS snapshots",
 					slog.String("name", *snapshot.DBSnapshotIdentifier),
 					slog.String("region", r.region),
@@ -88,14 +127,97 @@ func (r *RDSSnapshotScan) Scan(ctx context.Context, target string) ([]Result, er
 				continue
 			}
 
+			exposure, sharedWith := r.exposure(ctx, &snapshot, target)
+
 			output = append(output, Result{
-				CreationDate: snapshot.SnapshotCreateTime.Format(time.RFC3339),
-				Identifier:   *snapshot.DBSnapshotIdentifier,
-				Region:       r.region,
-				RType:        r.RunType(),
+				Account:          "",
+				BackingSnapshots: nil,
+				CreationDate:     snapshot.SnapshotCreateTime.Format(time.RFC3339),
+				Details:          rdsSnapshotDetails(&snapshot),
+				Engine:           aws.ToString(snapshot.Engine),
+				EngineVersion:    aws.ToString(snapshot.EngineVersion),
+				Exposure:         exposure,
+				FirstSeen:        "",
+				Identifier:       *snapshot.DBSnapshotIdentifier,
+				ParentImage:      "",
+				PolicyStatements: nil,
+				Region:           r.region,
+				RType:            r.RunType(),
+				Secrets:          nil,
+				Severity:         "",
+				SharedWith:       sharedWith,
+				Tags:             tagsToMap(snapshot.TagList, rdsTag),
 			})
 		}
 	}
 
 	return output, nil
 }
+
+// exposure returns who can restore the snapshot, based on its type or its restore attribute.
+func (r *RDSSnapshotScan) exposure(
+	ctx context.Context,
+	snapshot *types.DBSnapshot,
+	target string,
+) (Exposure, []string) {
+	switch aws.ToString(snapshot.SnapshotType) {
+	case rdsSnapshotTypePublic:
+		return ExposurePublic, nil
+	case rdsSnapshotTypeShared:
+		return externalExposure(ctx)
+	}
+
+	if isExternalScan(ctx, target) {
+		return externalExposure(ctx)
+	}
+
+	attributes, err := r.client.DescribeDBSnapshotAttributes(
+		ctx,
+		&rds.DescribeDBSnapshotAttributesInput{
+			DBSnapshotIdentifier: snapshot.DBSnapshotIdentifier,
+		},
+	)
+	if err != nil || attributes.DBSnapshotAttributesResult == nil {
+		slog.Debug("failed to describe RDS snapshot attributes",
+			slog.Any("error", err),
+			slog.String("name", *snapshot.DBSnapshotIdentifier),
+			slog.String("region", r.region),
+		)
+
+		return ExposureUnknown, nil
+	}
+
+	return exposureFromPermissions(
+		rdsRestoreAttributeValues(attributes.DBSnapshotAttributesResult.DBSnapshotAttributes),
+	)
+}
+
+// rdsSnapshotDetails returns the triage metadata of an RDS snapshot, named after its source instance.
+func rdsSnapshotDetails(snapshot *types.DBSnapshot) *Details {
+	return newDetails(Details{
+		ARN:               aws.ToString(snapshot.DBSnapshotArn),
+		ContentNotScanned: false,
+		Description:       "",
+		Encrypted:         snapshot.Encrypted,
+		KMSKeyID:          aws.ToString(snapshot.KmsKeyId),
+		Name:              aws.ToString(snapshot.DBInstanceIdentifier),
+		OwnerAlias:        "",
+		SizeGiB:           int64(aws.ToInt32(snapshot.AllocatedStorage)),
+	})
+}
+
+// rdsRestoreAttributeValues returns the values of the restore attribute of an RDS snapshot.
+func rdsRestoreAttributeValues(attributes []types.DBSnapshotAttribute) []string {
+	for _, attribute := range attributes {
+		if aws.ToString(attribute.AttributeName) == rdsRestoreAttribute {
+			return attribute.AttributeValues
+		}
+	}
+
+	return nil
+}
+
+// rdsTag returns the key and value of an RDS tag.
+func rdsTag(tag types.Tag) (*string, *string) {
+	return tag.Key, tag.Value
+}
@@ -16,8 +16,10 @@ import (
 )
 
 type mockRDSSnapshotClient struct {
-	mockDBSnapshot             []types.DBSnapshot
-	mockDescribeDBSnapshotsErr error
+	mockDBSnapshot                      []types.DBSnapshot
+	mockDescribeDBSnapshotsErr          error
+	mockRestoreAccounts                 []string
+	mockDescribeDBSnapshotAttributesErr error
 }
 
 func (m *mockRDSSnapshotClient) DescribeDBSnapshots(
@@ -30,6 +32,24 @@ func (m *mockRDSSnapshotClient) DescribeDBSnapshots(
 	}, m.mockDescribeDBSnapshotsErr
 }
 
+func (m *mockRDSSnapshotClient) DescribeDBSnapshotAttributes(
+	_ context.Context,
+	_ *rds.DescribeDBSnapshotAttributesInput,
+	_ ...func(*rds.Options),
+) (*rds.DescribeDBSnapshotAttributesOutput, error) {
+	return &rds.DescribeDBSnapshotAttributesOutput{
+		DBSnapshotAttributesResult: &types.DBSnapshotAttributesResult{
+			DBSnapshotAttributes: []types.DBSnapshotAttribute{
+				{
+					AttributeName:   aws.String(rdsRestoreAttribute),
+					AttributeValues: m.mockRestoreAccounts,
+				},
+			},
+			DBSnapshotIdentifier: nil,
+		},
+	}, m.mockDescribeDBSnapshotAttributesErr
+}
+
 var _ rdsSnapshotClient = (*mockRDSSnapshotClient)(nil)
 
 func Test_rdsSnapshotScan_scan(t *testing.T) {
@@ -88,24 +108,124 @@ func Test_rdsSnapshotScan_scan(t *testing.T) {
 			client: &mockRDSSnapshotClient{
 				mockDBSnapshot: []types.DBSnapshot{
 					{
-						SnapshotCreateTime:   &now,
+						SnapshotCreateTime: &now,
+						DBSnapshotArn: aws.String(
+							"arn:aws:rds:eu-west-1:123456789012:snapshot:test-self-id",
+						),
 						DBSnapshotIdentifier: aws.String("test-self-id"),
 					},
+					{
+						SnapshotCreateTime: &now,
+						DBSnapshotArn: aws.String(
+							"arn:aws:rds:eu-west-1:210987654321:snapshot:test-123456789012",
+						),
+						DBSnapshotIdentifier: aws.String("test-123456789012"),
+					},
 					{
 						SnapshotCreateTime:   &now,
-						DBSnapshotIdentifier: aws.String("test-skip-id"),
+						DBSnapshotArn:        nil,
+						DBSnapshotIdentifier: aws.String("test-invalid-arn-id"),
 					},
 				},
 				mockDescribeDBSnapshotsErr: nil,
 			},
 			region: "eu-west-1",
-			target: "self",
+			target: "123456789012",
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details: &Details{
+						ARN: "arn:aws:rds:eu-west-1:123456789012:snapshot:test-self-id",
+					},
+					Exposure:   ExposurePrivate,
+					Identifier: "test-self-id",
+					Region:     "eu-west-1",
+					RType:      SnapshotRDS,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should report public and shared snapshots by type",
+			ctx:  withCaller(t.Context(), "1337"),
+			client: &mockRDSSnapshotClient{
+				mockDBSnapshot: []types.DBSnapshot{
+					{
+						SnapshotCreateTime: &now,
+						DBSnapshotArn: aws.String(
+							"arn:aws:rds:eu-west-1:42:snapshot:public",
+						),
+						DBSnapshotIdentifier: aws.String(
+							"arn:aws:rds:eu-west-1:42:snapshot:public",
+						),
+						SnapshotType: aws.String("public"),
+					},
+					{
+						SnapshotCreateTime: &now,
+						DBSnapshotArn: aws.String(
+							"arn:aws:rds:eu-west-1:42:snapshot:shared",
+						),
+						DBSnapshotIdentifier: aws.String(
+							"arn:aws:rds:eu-west-1:42:snapshot:shared",
+						),
+						SnapshotType: aws.String("shared"),
+					},
+				},
+				mockDescribeDBSnapshotsErr:          nil,
+				mockDescribeDBSnapshotAttributesErr: errors.New("some error"),
+			},
+			region: "eu-west-1",
+			target: "42",
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details:      &Details{ARN: "arn:aws:rds:eu-west-1:42:snapshot:public"},
+					Exposure:     ExposurePublic,
+					Identifier:   "arn:aws:rds:eu-west-1:42:snapshot:public",
+					Region:       "eu-west-1",
+					RType:        SnapshotRDS,
+				},
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details:      &Details{ARN: "arn:aws:rds:eu-west-1:42:snapshot:shared"},
+					Exposure:     ExposureShared,
+					Identifier:   "arn:aws:rds:eu-west-1:42:snapshot:shared",
+					Region:       "eu-west-1",
+					RType:        SnapshotRDS,
+					SharedWith:   []string{"1337"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "should report restore accounts of owned snapshots",
+			ctx:  withCaller(t.Context(), "42"),
+			client: &mockRDSSnapshotClient{
+				mockDBSnapshot: []types.DBSnapshot{
+					{
+						SnapshotCreateTime: &now,
+						DBSnapshotArn: aws.String(
+							"arn:aws:rds:eu-west-1:42:snapshot:test-self-id",
+						),
+						DBSnapshotIdentifier: aws.String("test-self-id"),
+						SnapshotType:         aws.String("manual"),
+					},
+				},
+				mockDescribeDBSnapshotsErr:          nil,
+				mockRestoreAccounts:                 []string{"all", "1337"},
+				mockDescribeDBSnapshotAttributesErr: nil,
+			},
+			region: "eu-west-1",
+			target: "42",
 			want: []Result{
 				{
 					CreationDate: now.Format(time.RFC3339),
+					Details:      &Details{ARN: "arn:aws:rds:eu-west-1:42:snapshot:test-self-id"},
+					Exposure:     ExposurePublic,
 					Identifier:   "test-self-id",
 					Region:       "eu-west-1",
 					RType:        SnapshotRDS,
+					SharedWith:   []string{"1337"},
 				},
 			},
 			wantErr: false,
@@ -0,0 +1,176 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
+	"github.com/aws/aws-sdk-go-v2/service/redshift/types"
+)
+
+var (
+	_ redshift.DescribeClusterSnapshotsAPIClient = (redshiftSnapshotClient)(nil)
+	_ Runner                                     = (*RedshiftSnapshotScan)(nil)
+)
+
+type redshiftSnapshotClient interface {
+	redshift.DescribeClusterSnapshotsAPIClient
+}
+
+// RedshiftSnapshotFilter defines a function for filtering Redshift cluster snapshots.
+type RedshiftSnapshotFilter func(snapshot *types.Snapshot, target string) bool
+
+func isRedshiftSnapshotOwner(snapshot *types.Snapshot, target string) bool {
+	return snapshot.OwnerAccount == nil || *snapshot.OwnerAccount != target
+}
+
+// RedshiftSnapshotScan scans Redshift cluster snapshots in a region using a Redshift client and filter.
+type RedshiftSnapshotScan struct {
+	baseRunner
+
+	client redshiftSnapshotClient
+	filter RedshiftSnapshotFilter
+}
+
+// NewRedshiftSnapshotRunner creates a new RedshiftSnapshotScan with the given config and filter.
+func NewRedshiftSnapshotRunner(
+	cfg aws.Config,
+	filterFunc RedshiftSnapshotFilter,
+) *RedshiftSnapshotScan {
+	client := redshift.NewFromConfig(cfg)
+
+	return &RedshiftSnapshotScan{
+		baseRunner: baseRunner{
+			region:     cfg.Region,
+			runnerType: SnapshotRedshift,
+		},
+		client: client,
+		filter: filterFunc,
+	}
+}
+
+// Scan retrieves Redshift cluster snapshots owned by the target AWS account,
+// along with the accounts that can restore them.
+func (r *RedshiftSnapshotScan) Scan(ctx context.Context, target string) ([]Result, error) {
+	var output []Result
+
+	paginator := redshift.NewDescribeClusterSnapshotsPaginator(
+		r.client,
+		&redshift.DescribeClusterSnapshotsInput{
+			ClusterExists:      nil,
+			ClusterIdentifier:  nil,
+			EndTime:            nil,
+			Marker:             nil,
+			MaxRecords:         nil,
+			OwnerAccount:       aws.String(target),
+			SnapshotArn:        nil,
+			SnapshotIdentifier: nil,
+			SnapshotType:       nil,
+			SortingEntities:    nil,
+			StartTime:          nil,
+			TagKeys:            nil,
+			TagValues:          nil,
+		},
+	)
+	for paginator.HasMorePages() {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCtxCancelled, ctx.Err())
+		}
+
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch Redshift snapshots, %w", err)
+		}
+
+		for _, snapshot := range page.Snapshots {
+			if r.filter != nil && r.filter(&snapshot, target) {
+				slog.Debug("skipping Redshift snapshot",
+					slog.String("name", *snapshot.SnapshotIdentifier),
+					slog.String("region", r.region),
+				)
+
+				continue
+			}
+
+			exposure, sharedWith := redshiftExposure(ctx, &snapshot, target)
+
+			output = append(output, Result{
+				Account:          "",
+				BackingSnapshots: nil,
+				CreationDate:     snapshot.SnapshotCreateTime.Format(time.RFC3339),
+				Details:          redshiftSnapshotDetails(&snapshot),
+				Engine:           "",
+				EngineVersion:    "",
+				Exposure:         exposure,
+				FirstSeen:        "",
+				Identifier:       *snapshot.SnapshotIdentifier,
+				ParentImage:      "",
+				PolicyStatements: nil,
+				Region:           r.region,
+				RType:            r.RunType(),
+				Secrets:          nil,
+				Severity:         "",
+				SharedWith:       sharedWith,
+				Tags:             tagsToMap(snapshot.Tags, redshiftTag),
+			})
+		}
+	}
+
+	return output, nil
+}
+
+// redshiftExposure returns who can restore the snapshot, snapshots seen from another account are shared with it.
+func redshiftExposure(
+	ctx context.Context,
+	snapshot *types.Snapshot,
+	target string,
+) (Exposure, []string) {
+	if isExternalScan(ctx, target) {
+		return externalExposure(ctx)
+	}
+
+	return exposureFromPermissions(redshiftRestoreAccounts(snapshot.AccountsWithRestoreAccess))
+}
+
+// redshiftRestoreAccounts returns the IDs of the accounts that can restore a snapshot.
+func redshiftRestoreAccounts(accounts []types.AccountWithRestoreAccess) []string {
+	var output []string
+
+	for _, account := range accounts {
+		if account.AccountId != nil {
+			output = append(output, *account.AccountId)
+		}
+	}
+
+	return output
+}
+
+// redshiftSnapshotDetails returns the triage metadata of a Redshift snapshot, named after its source cluster.
+func redshiftSnapshotDetails(snapshot *types.Snapshot) *Details {
+	const megabytesPerGiB = 1024
+
+	return newDetails(Details{
+		ARN:               "",
+		ContentNotScanned: false,
+		Description:       "",
+		Encrypted:         snapshot.Encrypted,
+		KMSKeyID:          aws.ToString(snapshot.KmsKeyId),
+		Name:              aws.ToString(snapshot.ClusterIdentifier),
+		OwnerAlias:        "",
+		SizeGiB: int64(
+			math.Ceil(aws.ToFloat64(snapshot.TotalBackupSizeInMegaBytes) / megabytesPerGiB),
+		),
+	})
+}
+
+// redshiftTag returns the key and value of a Redshift tag.
+func redshiftTag(tag types.Tag) (*string, *string) {
+	return tag.Key, tag.Value
+}
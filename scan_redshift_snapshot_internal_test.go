@@ -0,0 +1,156 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshift"
+	"github.com/aws/aws-sdk-go-v2/service/redshift/types"
+)
+
+type mockRedshiftSnapshotClient struct {
+	mockSnapshots                   []types.Snapshot
+	mockDescribeClusterSnapshotsErr error
+}
+
+func (m *mockRedshiftSnapshotClient) DescribeClusterSnapshots(
+	_ context.Context,
+	_ *redshift.DescribeClusterSnapshotsInput,
+	_ ...func(*redshift.Options),
+) (*redshift.DescribeClusterSnapshotsOutput, error) {
+	return &redshift.DescribeClusterSnapshotsOutput{
+		Snapshots: m.mockSnapshots,
+	}, m.mockDescribeClusterSnapshotsErr
+}
+
+var _ redshiftSnapshotClient = (*mockRedshiftSnapshotClient)(nil)
+
+func Test_redshiftSnapshotScan_scan(t *testing.T) {
+	t.Parallel()
+	withTimeout, cancel := context.WithTimeout(t.Context(), -time.Minute)
+	defer cancel()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context //nolint:containedctx
+		client  redshiftSnapshotClient
+		region  string
+		target  string
+		want    []Result
+		wantErr bool
+	}{
+		{
+			name: "should fail when ctx is cancelled",
+			ctx:  withTimeout,
+			client: &mockRedshiftSnapshotClient{
+				mockSnapshots:                   nil,
+				mockDescribeClusterSnapshotsErr: nil,
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should fail when api returns error",
+			ctx:  t.Context(),
+			client: &mockRedshiftSnapshotClient{
+				mockSnapshots:                   nil,
+				mockDescribeClusterSnapshotsErr: errors.New("some error"),
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "should succeed with zero snapshots when no snapshots found",
+			ctx:  t.Context(),
+			client: &mockRedshiftSnapshotClient{
+				mockSnapshots:                   nil,
+				mockDescribeClusterSnapshotsErr: nil,
+			},
+			region:  "eu-west-1",
+			target:  "42",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "should succeed with one snapshot and its restore accounts",
+			ctx:  t.Context(),
+			client: &mockRedshiftSnapshotClient{
+				mockSnapshots: []types.Snapshot{
+					{
+						AccountsWithRestoreAccess: []types.AccountWithRestoreAccess{
+							{AccountId: aws.String("1337")},
+							{AccountAlias: aws.String("amazon-redshift-support")},
+						},
+						ClusterIdentifier:          aws.String("test-cluster"),
+						Encrypted:                  aws.Bool(false),
+						OwnerAccount:               aws.String("42"),
+						SnapshotCreateTime:         &now,
+						SnapshotIdentifier:         aws.String("test-snapshot-id"),
+						TotalBackupSizeInMegaBytes: aws.Float64(1500),
+					},
+					{
+						OwnerAccount:       aws.String("7"),
+						SnapshotCreateTime: &now,
+						SnapshotIdentifier: aws.String("test-skip-id"),
+					},
+				},
+				mockDescribeClusterSnapshotsErr: nil,
+			},
+			region: "eu-west-1",
+			target: "42",
+			want: []Result{
+				{
+					CreationDate: now.Format(time.RFC3339),
+					Details: &Details{
+						Encrypted: aws.Bool(false),
+						Name:      "test-cluster",
+						SizeGiB:   2,
+					},
+					Exposure:   ExposureShared,
+					Identifier: "test-snapshot-id",
+					Region:     "eu-west-1",
+					RType:      SnapshotRedshift,
+					SharedWith: []string{"1337"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &RedshiftSnapshotScan{
+				baseRunner: baseRunner{
+					region:     tt.region,
+					runnerType: SnapshotRedshift,
+				},
+				client: tt.client,
+				filter: isRedshiftSnapshotOwner,
+			}
+
+			got, err := r.Scan(tt.ctx, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("scan() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scan() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,6 +21,16 @@ var (
 
 type ssmDocumentClient interface {
 	ssm.ListDocumentsAPIClient
+	DescribeDocumentPermission(
+		ctx context.Context,
+		params *ssm.DescribeDocumentPermissionInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.DescribeDocumentPermissionOutput, error)
+	GetDocument(
+		ctx context.Context,
+		params *ssm.GetDocumentInput,
+		optFns ...func(*ssm.Options),
+	) (*ssm.GetDocumentOutput, error)
 }
 
 // SSMDocumentFilter defines a function for filtering SSM documents.
@@ -34,12 +44,18 @@ func isSSMDocumentOwner(document *types.DocumentIdentifier, target string) bool
 type SSMDocumentScan struct {
 	baseRunner
 
-	client ssmDocumentClient
-	filter SSMDocumentFilter
+	client    ssmDocumentClient
+	detectors []SecretDetector
+	filter    SSMDocumentFilter
 }
 
 // NewSSMDocumentScan creates a new SSMDocumentScan with the given config and filter.
-func NewSSMDocumentScan(cfg aws.Config, filterFunc SSMDocumentFilter) *SSMDocumentScan {
+// When detectors are given, the content of every shared document is fetched and searched for secrets.
+func NewSSMDocumentScan(
+	cfg aws.Config,
+	filterFunc SSMDocumentFilter,
+	detectors ...SecretDetector,
+) *SSMDocumentScan {
 	client := ssm.NewFromConfig(cfg)
 
 	return &SSMDocumentScan{
@@ -47,8 +63,9 @@ func NewSSMDocumentScan(cfg aws.Config, filterFunc SSMDocumentFilter) *SSMDocume
 			region:     cfg.Region,
 			runnerType: DocumentSSM,
 		},
-		client: client,
-		filter: filterFunc,
+		client:    client,
+		detectors: detectors,
+		filter:    filterFunc,
 	}
 }
 
@@ -88,14 +105,122 @@ func (s SSMDocumentScan) Scan(ctx context.Context, target string) ([]Result, err
 				continue
 			}
 
+			exposure, sharedWith := s.exposure(ctx, &document, target)
+			secrets, scanned := s.secrets(ctx, &document, exposure)
+
 			output = append(output, Result{
-				CreationDate: document.CreatedDate.Format(time.RFC3339),
-				Identifier:   *document.Name,
-				Region:       s.region,
-				RType:        s.RunType(),
+				Account:          "",
+				BackingSnapshots: nil,
+				CreationDate:     document.CreatedDate.Format(time.RFC3339),
+				Details:          ssmDocumentDetails(&document, !scanned),
+				Engine:           "",
+				EngineVersion:    "",
+				Exposure:         exposure,
+				FirstSeen:        "",
+				Identifier:       *document.Name,
+				ParentImage:      "",
+				PolicyStatements: nil,
+				Region:           s.region,
+				RType:            s.RunType(),
+				Secrets:          secrets,
+				Severity:         "",
+				SharedWith:       sharedWith,
+				Tags:             tagsToMap(document.Tags, ssmTag),
 			})
 		}
 	}
 
 	return output, nil
 }
+
+// exposure returns who can use the document, based on its share permissions.
+// Documents are listed with the Public owner filter, so documents of another account are public.
+func (s SSMDocumentScan) exposure(
+	ctx context.Context,
+	document *types.DocumentIdentifier,
+	target string,
+) (Exposure, []string) {
+	if isExternalScan(ctx, target) {
+		return ExposurePublic, nil
+	}
+
+	var permissions []string
+
+	input := &ssm.DescribeDocumentPermissionInput{
+		Name:           document.Name,
+		PermissionType: types.DocumentPermissionTypeShare,
+		MaxResults:     nil,
+		NextToken:      nil,
+	}
+	for {
+		page, err := s.client.DescribeDocumentPermission(ctx, input)
+		if err != nil {
+			slog.Debug("failed to describe SSM document permissions",
+				slog.String("error", err.Error()),
+				slog.String("name", *document.Name),
+				slog.String("region", s.region),
+			)
+
+			return ExposureUnknown, nil
+		}
+
+		permissions = append(permissions, page.AccountIds...)
+
+		if aws.ToString(page.NextToken) == "" {
+			break
+		}
+
+		input.NextToken = page.NextToken
+	}
+
+	return exposureFromPermissions(permissions)
+}
+
+// secrets fetches the content of a shared document and returns the secrets found by the detectors.
+// It reports false when the content should have been searched but could not be fetched.
+func (s SSMDocumentScan) secrets(
+	ctx context.Context,
+	document *types.DocumentIdentifier,
+	exposure Exposure,
+) ([]SecretFinding, bool) {
+	if len(s.detectors) == 0 || exposure == ExposurePrivate {
+		return nil, true
+	}
+
+	content, err := s.client.GetDocument(ctx, &ssm.GetDocumentInput{
+		Name:            document.Name,
+		DocumentFormat:  "",
+		DocumentVersion: document.DocumentVersion,
+		VersionName:     nil,
+	})
+	if err != nil {
+		slog.Warn("failed to get SSM document content, it was not searched for secrets",
+			slog.String("error", err.Error()),
+			slog.String("name", *document.Name),
+			slog.String("region", s.region),
+		)
+
+		return nil, false
+	}
+
+	return detectSecrets(aws.ToString(content.Content), s.detectors), true
+}
+
+// ssmDocumentDetails returns the triage metadata of an SSM document.
+func ssmDocumentDetails(document *types.DocumentIdentifier, notScanned bool) *Details {
+	return newDetails(Details{
+		ARN:               "",
+		ContentNotScanned: notScanned,
+		Description:       "",
+		Encrypted:         nil,
+		KMSKeyID:          "",
+		Name:              aws.ToString(document.DisplayName),
+		OwnerAlias:        "",
+		SizeGiB:           0,
+	})
+}
+
+// ssmTag returns the key and value of an SSM tag.
+func ssmTag(tag types.Tag) (*string, *string) {
+	return tag.Key, tag.Value
+}
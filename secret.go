@@ -0,0 +1,183 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// SecretFinding is a possible secret found in the content of a resource.
+type SecretFinding struct {
+	// Rule is the ID of the SecretDetector that matched.
+	Rule string `json:"rule"`
+	// Line is the 1-based line number of the match.
+	Line int `json:"line"`
+	// Match is the redacted matched value.
+	Match string `json:"match"`
+}
+
+// SecretDetector finds secrets in a single line of text.
+type SecretDetector interface {
+	// RuleID returns the ID reported with every match of the detector.
+	RuleID() string
+	// Detect returns the secret values found in the line.
+	Detect(line string) []string
+}
+
+var (
+	_ SecretDetector = (*regexpDetector)(nil)
+	_ SecretDetector = (*entropyDetector)(nil)
+)
+
+// regexpDetector reports the submatch at group of every match of pattern, or the whole match when group is 0.
+type regexpDetector struct {
+	group   int
+	pattern *regexp.Regexp
+	ruleID  string
+}
+
+// NewRegexpDetector returns a SecretDetector reporting matches of pattern, or of its group-th submatch when group > 0.
+func NewRegexpDetector(ruleID string, pattern *regexp.Regexp, group int) SecretDetector {
+	return &regexpDetector{
+		group:   group,
+		pattern: pattern,
+		ruleID:  ruleID,
+	}
+}
+
+func (d *regexpDetector) RuleID() string {
+	return d.ruleID
+}
+
+func (d *regexpDetector) Detect(line string) []string {
+	var output []string
+
+	for _, match := range d.pattern.FindAllStringSubmatch(line, -1) {
+		if d.group < len(match) && match[d.group] != "" {
+			output = append(output, match[d.group])
+		}
+	}
+
+	return output
+}
+
+// entropyDetector reports long tokens that look random, like keys and tokens without a well-known prefix.
+type entropyDetector struct {
+	minEntropy float64
+	minLength  int
+	tokens     *regexp.Regexp
+}
+
+func (d *entropyDetector) RuleID() string {
+	return "high-entropy-string"
+}
+
+func (d *entropyDetector) Detect(line string) []string {
+	var output []string
+
+	for _, token := range d.tokens.FindAllString(line, -1) {
+		if len(token) >= d.minLength && shannonEntropy(token) >= d.minEntropy {
+			output = append(output, token)
+		}
+	}
+
+	return output
+}
+
+// DefaultSecretDetectors returns detectors for AWS keys, private keys, connection strings, and high-entropy strings.
+func DefaultSecretDetectors() []SecretDetector { //nolint:mnd // entropy thresholds
+	return []SecretDetector{
+		NewRegexpDetector(
+			"aws-access-key-id",
+			regexp.MustCompile(`\b((?:AKIA|ASIA|ABIA|ACCA)[A-Z2-7]{16})\b`),
+			1,
+		),
+		NewRegexpDetector(
+			"aws-secret-access-key",
+			regexp.MustCompile(
+				`(?i)aws.{0,20}?(?:secret|key).{0,20}?[=:\s'"]+([A-Za-z0-9/+]{40})\b`,
+			),
+			1,
+		),
+		NewRegexpDetector(
+			"private-key",
+			regexp.MustCompile(`-----BEGIN[A-Z ]* PRIVATE KEY( BLOCK)?-----`),
+			0,
+		),
+		NewRegexpDetector(
+			"connection-string",
+			regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^\s:/@'"]+:([^\s@/'"]+)@`),
+			1,
+		),
+		NewRegexpDetector(
+			"connection-string",
+			regexp.MustCompile(`(?i)\b(?:password|pwd)\s*=\s*([^;\s'"]+)`),
+			1,
+		),
+		&entropyDetector{
+			minEntropy: 4.5,
+			minLength:  32,
+			tokens:     regexp.MustCompile(`[A-Za-z0-9+/_-]+=*`),
+		},
+	}
+}
+
+// detectSecrets runs the detectors on every line of the content, a value is only reported by the first detector
+// that matches it.
+func detectSecrets(content string, detectors []SecretDetector) []SecretFinding {
+	var output []SecretFinding
+
+	for idx, line := range strings.Split(content, "\n") {
+		seen := make(map[string]struct{})
+
+		for _, detector := range detectors {
+			for _, value := range detector.Detect(line) {
+				if _, ok := seen[value]; ok {
+					continue
+				}
+
+				seen[value] = struct{}{}
+
+				output = append(output, SecretFinding{
+					Rule:  detector.RuleID(),
+					Line:  idx + 1,
+					Match: redact(value),
+				})
+			}
+		}
+	}
+
+	return output
+}
+
+// redact keeps the first characters of long values to help with triage, and hides the rest.
+func redact(value string) string {
+	const visible = 4
+
+	if len(value) <= 2*visible {
+		return "****"
+	}
+
+	return value[:visible] + "****"
+}
+
+// shannonEntropy returns the entropy of the value in bits per character.
+func shannonEntropy(value string) float64 {
+	counts := make(map[rune]int)
+	for _, char := range value {
+		counts[char]++
+	}
+
+	var entropy float64
+
+	length := float64(len(value))
+	for _, count := range counts {
+		frequency := float64(count) / length
+		entropy -= frequency * math.Log2(frequency)
+	}
+
+	return entropy
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Severity ranks how harmful a finding is.
+type Severity string
+
+const (
+	// SeverityInfo is used for findings that are unlikely to expose data.
+	SeverityInfo Severity = "info"
+	// SeverityLow is used for findings with a limited impact.
+	SeverityLow Severity = "low"
+	// SeverityMedium is used for findings that should be reviewed.
+	SeverityMedium Severity = "medium"
+	// SeverityHigh is used for findings that likely expose sensitive data.
+	SeverityHigh Severity = "high"
+	// SeverityCritical is used for findings that expose sensitive data to everyone.
+	SeverityCritical Severity = "critical"
+)
+
+// ParseSeverity parses a severity name, ignoring case.
+func ParseSeverity(value string) (Severity, error) {
+	severity := Severity(strings.ToLower(strings.TrimSpace(value)))
+	if severity.rank() == 0 {
+		return "", fmt.Errorf("%w: %s", ErrUnknownSeverity, value)
+	}
+
+	return severity, nil
+}
+
+// severities lists the severities from the lowest to the highest.
+func severities() []Severity {
+	return []Severity{SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+}
+
+// rank orders severities from info (1) to critical (5), it is 0 for unknown values.
+func (s Severity) rank() int {
+	return slices.Index(severities(), s) + 1
+}
+
+// AgeRule adds Score to findings older than Days.
+type AgeRule struct {
+	Days  int `json:"days"  yaml:"days"`
+	Score int `json:"score" yaml:"score"`
+}
+
+// SizeRule adds Score to findings of at least GiB in size.
+type SizeRule struct {
+	GiB   int64 `json:"gib"   yaml:"gib"`
+	Score int   `json:"score" yaml:"score"`
+}
+
+// SeverityThresholds lists the minimal score of each severity, lower scores are SeverityInfo.
+type SeverityThresholds struct {
+	Critical int `json:"critical" yaml:"critical"`
+	High     int `json:"high"     yaml:"high"`
+	Medium   int `json:"medium"   yaml:"medium"`
+	Low      int `json:"low"      yaml:"low"`
+}
+
+// ScoringRules computes the severity of a finding by adding up the scores of the rules that apply to it.
+type ScoringRules struct {
+	// Types holds the base score of each resource type, as listed by -list-scanners.
+	Types map[string]int `json:"types"       yaml:"types"`
+	// Exposure holds the score added for each exposure level.
+	Exposure map[Exposure]int `json:"exposure"    yaml:"exposure"`
+	// Unencrypted is added when the resource is known to be unencrypted.
+	Unencrypted int `json:"unencrypted" yaml:"unencrypted"`
+	// Secrets is added when possible secrets were found in the content of the resource.
+	Secrets int `json:"secrets"     yaml:"secrets"`
+	// Age is added to resources created a long time ago, which are more likely to be forgotten.
+	Age AgeRule `json:"age"         yaml:"age"`
+	// Size is added to large resources, which are more likely to hold data.
+	Size SizeRule `json:"size"        yaml:"size"`
+	// Thresholds maps the total score to a Severity.
+	Thresholds SeverityThresholds `json:"thresholds"  yaml:"thresholds"`
+}
+
+// DefaultScoringRules returns the rules used when no rules file is provided.
+func DefaultScoringRules() *ScoringRules { //nolint:mnd // default weights
+	return &ScoringRules{
+		Types: map[string]int{
+			ImageAMI.String():         20,
+			SnapshotEBS.String():      30,
+			SnapshotRDS.String():      30,
+			DocumentSSM.String():      10,
+			LayerLambda.String():      10,
+			SnapshotRedshift.String(): 30,
+			SnapshotDocDB.String():    30,
+			SnapshotNeptune.String():  30,
+			RepositoryECR.String():    10,
+		},
+		Exposure: map[Exposure]int{
+			ExposurePublic:  40,
+			ExposureShared:  15,
+			ExposurePrivate: 0,
+			ExposureUnknown: 10,
+		},
+		Unencrypted: 20,
+		Secrets:     40,
+		Age: AgeRule{
+			Days:  365,
+			Score: 10,
+		},
+		Size: SizeRule{
+			GiB:   100,
+			Score: 10,
+		},
+		Thresholds: SeverityThresholds{
+			Critical: 80,
+			High:     60,
+			Medium:   40,
+			Low:      20,
+		},
+	}
+}
+
+// LoadScoringRules reads a YAML or JSON rules file, values it does not set keep their defaults.
+func LoadScoringRules(filePath string) (*ScoringRules, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring rules, %w", err)
+	}
+
+	rules := DefaultScoringRules()
+	types := rules.Types
+	rules.Types = nil
+
+	err = yaml.Unmarshal(data, rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scoring rules %s, %w", filePath, err)
+	}
+
+	// resource types are matched ignoring case, store them under their canonical name
+	for name, score := range rules.Types {
+		runners := GetRunners([]string{name})
+		if len(runners) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownRunnerType, name)
+		}
+
+		types[runners[0].String()] = score
+	}
+
+	rules.Types = types
+
+	return rules, nil
+}
+
+// Score returns the total score of the result at the given time.
+func (s *ScoringRules) Score(result *Result, now time.Time) int {
+	score := s.Types[result.RType.String()] + s.Exposure[result.Exposure]
+
+	if len(result.Secrets) > 0 {
+		score += s.Secrets
+	}
+
+	if result.Details != nil {
+		if result.Details.Encrypted != nil && !*result.Details.Encrypted {
+			score += s.Unencrypted
+		}
+
+		if s.Size.GiB > 0 && result.Details.SizeGiB >= s.Size.GiB {
+			score += s.Size.Score
+		}
+	}
+
+	created, ok := parseCreationDate(result.CreationDate)
+	if ok && s.Age.Days > 0 && created.Before(now.AddDate(0, 0, -s.Age.Days)) {
+		score += s.Age.Score
+	}
+
+	return score
+}
+
+// Severity returns the severity of the result at the given time.
+func (s *ScoringRules) Severity(result *Result, now time.Time) Severity {
+	score := s.Score(result, now)
+
+	switch {
+	case score >= s.Thresholds.Critical:
+		return SeverityCritical
+	case score >= s.Thresholds.High:
+		return SeverityHigh
+	case score >= s.Thresholds.Medium:
+		return SeverityMedium
+	case score >= s.Thresholds.Low:
+		return SeverityLow
+	default:
+		return SeverityInfo
+	}
+}
+
+// parseCreationDate parses the creation date formats used by the scanned AWS services.
+func parseCreationDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000-0700"} {
+		created, err := time.Parse(layout, value)
+		if err == nil {
+			return created, true
+		}
+	}
+
+	return time.Time{}, false
+}
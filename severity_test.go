@@ -0,0 +1,144 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/wakeful/spark"
+)
+
+func Test_ScoringRulesSeverity(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		result spark.Result
+		want   spark.Severity
+	}{
+		{
+			name: "public unencrypted RDS snapshot",
+			result: spark.Result{
+				CreationDate: "2025-05-01T00:00:00Z",
+				Details:      &spark.Details{Encrypted: aws.Bool(false)},
+				Exposure:     spark.ExposurePublic,
+				RType:        spark.SnapshotRDS,
+			},
+			want: spark.SeverityCritical,
+		},
+		{
+			name: "public SSM document",
+			result: spark.Result{
+				CreationDate: "2025-05-01T00:00:00Z",
+				Exposure:     spark.ExposurePublic,
+				RType:        spark.DocumentSSM,
+			},
+			want: spark.SeverityMedium,
+		},
+		{
+			name: "shared encrypted EBS snapshot",
+			result: spark.Result{
+				CreationDate: "2025-05-01T00:00:00Z",
+				Details:      &spark.Details{Encrypted: aws.Bool(true), SizeGiB: 8},
+				Exposure:     spark.ExposureShared,
+				RType:        spark.SnapshotEBS,
+			},
+			want: spark.SeverityMedium,
+		},
+		{
+			name: "old and large shared EBS snapshot",
+			result: spark.Result{
+				CreationDate: "2023-01-01T00:00:00Z",
+				Details:      &spark.Details{Encrypted: aws.Bool(true), SizeGiB: 500},
+				Exposure:     spark.ExposureShared,
+				RType:        spark.SnapshotEBS,
+			},
+			want: spark.SeverityHigh,
+		},
+		{
+			name: "old Lambda layer with the Lambda date format",
+			result: spark.Result{
+				CreationDate: "2023-01-01T00:00:00.000+0000",
+				Exposure:     spark.ExposurePrivate,
+				RType:        spark.LayerLambda,
+			},
+			want: spark.SeverityLow,
+		},
+		{
+			name: "private Lambda layer",
+			result: spark.Result{
+				CreationDate: "2025-05-01T00:00:00.000+0000",
+				Exposure:     spark.ExposurePrivate,
+				RType:        spark.LayerLambda,
+			},
+			want: spark.SeverityInfo,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := spark.DefaultScoringRules().Severity(&tt.result, now)
+			if got != tt.want {
+				t.Errorf("Severity() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_LoadScoringRules(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "rules.yaml")
+	invalid := filepath.Join(dir, "invalid.yaml")
+
+	err := os.WriteFile(
+		valid,
+		[]byte("types:\n  documentssm: 50\nthresholds:\n  critical: 90\n"),
+		0o600,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(invalid, []byte("types:\n  bucketS3: 50\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := spark.LoadScoringRules(valid)
+	if err != nil {
+		t.Fatalf("LoadScoringRules() error = %v", err)
+	}
+
+	defaults := spark.DefaultScoringRules()
+
+	if rules.Types[spark.DocumentSSM.String()] != 50 {
+		t.Errorf(
+			"LoadScoringRules() DocumentSSM score = %d, want 50",
+			rules.Types[spark.DocumentSSM.String()],
+		)
+	}
+
+	if rules.Types[spark.SnapshotRDS.String()] != defaults.Types[spark.SnapshotRDS.String()] {
+		t.Errorf("LoadScoringRules() did not keep the default score of %s", spark.SnapshotRDS)
+	}
+
+	if rules.Thresholds.Critical != 90 || rules.Thresholds.High != defaults.Thresholds.High {
+		t.Errorf("LoadScoringRules() thresholds = %+v", rules.Thresholds)
+	}
+
+	_, err = spark.LoadScoringRules(invalid)
+	if !errors.Is(err, spark.ErrUnknownRunnerType) {
+		t.Errorf("LoadScoringRules() error = %v, want %v", err, spark.ErrUnknownRunnerType)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"regexp"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Suppression marks resources that are shared on purpose, so they are reported apart from the findings.
+// Every criterion that is set must match, and at least one of them is required.
+type Suppression struct {
+	// Identifier matches the resource identifier exactly.
+	Identifier string `yaml:"identifier"`
+	// Name is a glob matched against the resource name, or its identifier when the name is unknown.
+	Name string `yaml:"name"`
+	// NameRegex is a regular expression matched against the resource name, or its identifier when the name is unknown.
+	NameRegex string `yaml:"nameRegex"`
+	// Region matches the region of the resource.
+	Region string `yaml:"region"`
+	// Type matches the resource type, as listed by -list-scanners.
+	Type string `yaml:"type"`
+	// Tags lists tags the resource must carry with the given values.
+	Tags map[string]string `yaml:"tags"`
+	// Reason explains why the resource is shared, it is mandatory.
+	Reason string `yaml:"reason"`
+	// Expires is an optional date (YYYY-MM-DD) after which the suppression no longer applies.
+	Expires string `yaml:"expires"`
+
+	expires   time.Time
+	nameRegex *regexp.Regexp
+	rType     RunnerType
+}
+
+// SuppressedResult is a Result matched by a Suppression.
+type SuppressedResult struct {
+	Result
+
+	Reason  string `json:"reason"`
+	Expires string `json:"expires,omitempty"`
+}
+
+// Suppressions holds the rules loaded from a suppression file.
+type Suppressions struct {
+	rules []Suppression
+}
+
+// LoadSuppressions reads a YAML or JSON suppression file with a top-level "suppressions" list.
+func LoadSuppressions(filePath string) (*Suppressions, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suppressions, %w", err)
+	}
+
+	var file struct {
+		Suppressions []Suppression `yaml:"suppressions"`
+	}
+
+	err = yaml.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse suppressions %s, %w", filePath, err)
+	}
+
+	for idx := range file.Suppressions {
+		err = file.Suppressions[idx].compile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid suppression #%d, %w", idx+1, err)
+		}
+	}
+
+	return &Suppressions{rules: file.Suppressions}, nil
+}
+
+// Apply moves results matched by an active suppression from the report results to its suppressed list.
+// Results matched only by expired suppressions stay in the results, so they resurface as findings.
+func (s *Suppressions) Apply(report *Report, now time.Time) {
+	results := make([]Result, 0, len(report.Results))
+
+	for _, result := range report.Results {
+		suppression, expired := s.match(&result, now)
+		if suppression == nil {
+			if expired != nil {
+				slog.Warn("suppression expired",
+					slog.String("expires", expired.Expires),
+					slog.String("identifier", result.Identifier),
+					slog.String("reason", expired.Reason),
+					slog.String("region", result.Region),
+				)
+			}
+
+			results = append(results, result)
+
+			continue
+		}
+
+		report.Suppressed = append(report.Suppressed, SuppressedResult{
+			Result:  result,
+			Reason:  suppression.Reason,
+			Expires: suppression.Expires,
+		})
+	}
+
+	report.Results = results
+}
+
+// match returns the first active suppression matching the result, or else the last expired one.
+func (s *Suppressions) match(result *Result, now time.Time) (*Suppression, *Suppression) {
+	var expired *Suppression
+
+	for idx := range s.rules {
+		rule := &s.rules[idx]
+		if !rule.matches(result) {
+			continue
+		}
+
+		if !rule.expires.IsZero() && now.After(rule.expires) {
+			expired = rule
+
+			continue
+		}
+
+		return rule, nil
+	}
+
+	return nil, expired
+}
+
+// compile validates the suppression and prepares its matchers.
+func (s *Suppression) compile() error {
+	if s.Reason == "" {
+		return ErrSuppressionReason
+	}
+
+	if s.Identifier == "" && s.Name == "" && s.NameRegex == "" &&
+		s.Region == "" && s.Type == "" && len(s.Tags) == 0 {
+		return ErrSuppressionEmpty
+	}
+
+	if s.Name != "" {
+		_, err := path.Match(s.Name, "")
+		if err != nil {
+			return fmt.Errorf("failed to parse name %q, %w", s.Name, err)
+		}
+	}
+
+	if s.NameRegex != "" {
+		compiled, err := regexp.Compile(s.NameRegex)
+		if err != nil {
+			return fmt.Errorf("failed to parse nameRegex, %w", err)
+		}
+
+		s.nameRegex = compiled
+	}
+
+	if s.Type != "" {
+		runners := GetRunners([]string{s.Type})
+		if len(runners) == 0 {
+			return fmt.Errorf("%w: %s", ErrUnknownRunnerType, s.Type)
+		}
+
+		s.rType = runners[0]
+	}
+
+	if s.Expires != "" {
+		expires, err := time.Parse(time.DateOnly, s.Expires)
+		if err != nil {
+			return fmt.Errorf("failed to parse expires, %w", err)
+		}
+
+		// the suppression applies until the end of the expiry day
+		s.expires = expires.AddDate(0, 0, 1)
+	}
+
+	return nil
+}
+
+// matches reports whether every criterion set on the suppression matches the result.
+func (s *Suppression) matches(result *Result) bool {
+	name := result.Identifier
+	if result.Details != nil && result.Details.Name != "" {
+		name = result.Details.Name
+	}
+
+	switch {
+	case s.Identifier != "" && s.Identifier != result.Identifier,
+		s.Region != "" && s.Region != result.Region,
+		s.Type != "" && s.rType != result.RType,
+		s.nameRegex != nil && !s.nameRegex.MatchString(name):
+		return false
+	}
+
+	if s.Name != "" {
+		matched, _ := path.Match(s.Name, name)
+		if !matched {
+			return false
+		}
+	}
+
+	for key, value := range s.Tags {
+		tag, ok := result.Tags[key]
+		if !ok || tag != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wakeful/spark"
+)
+
+func writeSuppressions(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), name)
+
+	err := os.WriteFile(filePath, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filePath
+}
+
+func Test_LoadSuppressions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name: "YAML file",
+			file: "suppressions.yaml",
+			content: `suppressions:
+  - type: AMI
+    name: "golden-*"
+    reason: public base images
+    expires: 2025-12-31
+`,
+			wantErr: false,
+			errIs:   nil,
+		},
+		{
+			name:    "JSON file",
+			file:    "suppressions.json",
+			content: `{"suppressions":[{"identifier":"snap-1","tags":{"team":"infra"},"reason":"audit"}]}`,
+			wantErr: false,
+			errIs:   nil,
+		},
+		{
+			name:    "should fail without reason",
+			file:    "suppressions.yaml",
+			content: "suppressions:\n  - identifier: snap-1\n",
+			wantErr: true,
+			errIs:   spark.ErrSuppressionReason,
+		},
+		{
+			name:    "should fail without criteria",
+			file:    "suppressions.yaml",
+			content: "suppressions:\n  - reason: everything\n",
+			wantErr: true,
+			errIs:   spark.ErrSuppressionEmpty,
+		},
+		{
+			name:    "should fail with unknown type",
+			file:    "suppressions.yaml",
+			content: "suppressions:\n  - type: bucketS3\n    reason: audit\n",
+			wantErr: true,
+			errIs:   spark.ErrUnknownRunnerType,
+		},
+		{
+			name:    "should fail with invalid regex",
+			file:    "suppressions.yaml",
+			content: "suppressions:\n  - nameRegex: \"(\"\n    reason: audit\n",
+			wantErr: true,
+			errIs:   nil,
+		},
+		{
+			name:    "should fail with invalid expiry date",
+			file:    "suppressions.yaml",
+			content: "suppressions:\n  - identifier: snap-1\n    reason: audit\n    expires: 31/12/2025\n",
+			wantErr: true,
+			errIs:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := spark.LoadSuppressions(writeSuppressions(t, tt.file, tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadSuppressions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("LoadSuppressions() error = %v, want %v", err, tt.errIs)
+			}
+		})
+	}
+}
+
+func Test_SuppressionsApply(t *testing.T) {
+	t.Parallel()
+
+	filePath := writeSuppressions(t, "suppressions.yaml", `suppressions:
+  - type: AMI
+    name: "golden-*"
+    reason: public base images
+  - nameRegex: "^backup-[0-9]+$"
+    region: eu-west-1
+    reason: restore drills
+  - identifier: snap-1
+    reason: shared with the audit account
+    expires: 2025-06-30
+  - tags:
+      sharing: approved
+    reason: approved by security
+`)
+
+	suppressions, err := spark.LoadSuppressions(filePath)
+	if err != nil {
+		t.Fatalf("LoadSuppressions() error = %v", err)
+	}
+
+	golden := spark.Result{
+		Details:    &spark.Details{Name: "golden-base"},
+		Identifier: "ami-1",
+		Region:     "eu-west-1",
+		RType:      spark.ImageAMI,
+	}
+	goldenSnapshot := spark.Result{
+		Details:    &spark.Details{Name: "golden-base"},
+		Identifier: "snap-2",
+		Region:     "eu-west-1",
+		RType:      spark.SnapshotEBS,
+	}
+	backup := spark.Result{Identifier: "backup-42", Region: "eu-west-1", RType: spark.SnapshotRDS}
+	backupOtherRegion := spark.Result{
+		Identifier: "backup-42",
+		Region:     "us-east-1",
+		RType:      spark.SnapshotRDS,
+	}
+	expiring := spark.Result{Identifier: "snap-1", Region: "eu-west-1", RType: spark.SnapshotEBS}
+	tagged := spark.Result{
+		Identifier: "doc-1",
+		Region:     "eu-west-1",
+		RType:      spark.DocumentSSM,
+		Tags:       map[string]string{"sharing": "approved", "team": "infra"},
+	}
+	otherTag := spark.Result{
+		Identifier: "doc-2",
+		Region:     "eu-west-1",
+		RType:      spark.DocumentSSM,
+		Tags:       map[string]string{"sharing": "pending"},
+	}
+
+	tests := []struct {
+		name           string
+		now            time.Time
+		wantResults    []spark.Result
+		wantSuppressed []spark.SuppressedResult
+	}{
+		{
+			name:        "before expiry",
+			now:         time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC),
+			wantResults: []spark.Result{goldenSnapshot, backupOtherRegion, otherTag},
+			wantSuppressed: []spark.SuppressedResult{
+				{Result: golden, Reason: "public base images"},
+				{Result: backup, Reason: "restore drills"},
+				{Result: expiring, Reason: "shared with the audit account", Expires: "2025-06-30"},
+				{Result: tagged, Reason: "approved by security"},
+			},
+		},
+		{
+			name:        "expired suppression resurfaces the result",
+			now:         time.Date(2025, 7, 1, 0, 0, 1, 0, time.UTC),
+			wantResults: []spark.Result{goldenSnapshot, backupOtherRegion, expiring, otherTag},
+			wantSuppressed: []spark.SuppressedResult{
+				{Result: golden, Reason: "public base images"},
+				{Result: backup, Reason: "restore drills"},
+				{Result: tagged, Reason: "approved by security"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := &spark.Report{
+				Results: []spark.Result{
+					golden, goldenSnapshot, backup, backupOtherRegion, expiring, tagged, otherTag,
+				},
+			}
+
+			suppressions.Apply(report, tt.now)
+
+			if !reflect.DeepEqual(report.Results, tt.wantResults) {
+				t.Errorf("Apply() results = %v, want %v", report.Results, tt.wantResults)
+			}
+
+			if !reflect.DeepEqual(report.Suppressed, tt.wantSuppressed) {
+				t.Errorf("Apply() suppressed = %v, want %v", report.Suppressed, tt.wantSuppressed)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"flag"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
@@ -29,9 +30,18 @@ func (s *StringSlice) Set(value string) error {
 var _ flag.Value = (*StringSlice)(nil)
 
 type stsClient interface {
+	AssumeRole(
+		ctx context.Context,
+		params *sts.AssumeRoleInput,
+		optFns ...func(*sts.Options),
+	) (*sts.AssumeRoleOutput, error)
 	GetCallerIdentity(
 		ctx context.Context,
 		params *sts.GetCallerIdentityInput,
 		optFns ...func(*sts.Options),
 	) (*sts.GetCallerIdentityOutput, error)
 }
+
+type organizationsClient interface {
+	organizations.ListAccountsAPIClient
+}
@@ -5,16 +5,40 @@ package spark
 
 import (
 	"context"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgTypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	stsTypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
 )
 
 type mockSTSClient struct {
 	mockAccountID            string
+	mockAssumeRoleErr        error
 	mockGetCallerIdentityErr error
 }
 
+func (m *mockSTSClient) AssumeRole(
+	_ context.Context,
+	params *sts.AssumeRoleInput,
+	_ ...func(*sts.Options),
+) (*sts.AssumeRoleOutput, error) {
+	if m.mockAssumeRoleErr != nil {
+		return nil, m.mockAssumeRoleErr
+	}
+
+	return &sts.AssumeRoleOutput{
+		Credentials: &stsTypes.Credentials{
+			AccessKeyId:     params.RoleArn,
+			Expiration:      aws.Time(time.Now().Add(time.Hour)),
+			SecretAccessKey: params.RoleSessionName,
+			SessionToken:    params.ExternalId,
+		},
+	}, nil
+}
+
 func (m *mockSTSClient) GetCallerIdentity(
 	_ context.Context,
 	_ *sts.GetCallerIdentityInput,
@@ -26,3 +50,20 @@ func (m *mockSTSClient) GetCallerIdentity(
 }
 
 var _ stsClient = (*mockSTSClient)(nil)
+
+type mockOrganizationsClient struct {
+	mockAccounts        []orgTypes.Account
+	mockListAccountsErr error
+}
+
+func (m *mockOrganizationsClient) ListAccounts(
+	_ context.Context,
+	_ *organizations.ListAccountsInput,
+	_ ...func(*organizations.Options),
+) (*organizations.ListAccountsOutput, error) {
+	return &organizations.ListAccountsOutput{
+		Accounts: m.mockAccounts,
+	}, m.mockListAccountsErr
+}
+
+var _ organizationsClient = (*mockOrganizationsClient)(nil)
@@ -5,7 +5,6 @@ package spark
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -13,6 +12,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 )
 
 var (
@@ -25,9 +26,39 @@ var (
 		"no resource types specified; use -list-scanners, -scan <type>, or -scan-all",
 	)
 	// ErrEmptyRegion is returned when no AWS regions are specified.
-	ErrEmptyRegion = errors.New("no AWS regions specified; use -region <name> or -region-all")
+	ErrEmptyRegion = errors.New(
+		"no AWS regions specified; use -region <name>, -region-all, or a profile with a default region",
+	)
+	// ErrEmptyOrganization is returned when the AWS Organization has no active accounts.
+	ErrEmptyOrganization = errors.New("no active accounts found in the AWS Organization")
 	// ErrEmptyTarget indicates a missing target AWS account ID.
 	ErrEmptyTarget = errors.New("empty target AWS account ID")
+	// ErrUnknownRunnerType is returned when a resource type name does not match any RunnerType.
+	ErrUnknownRunnerType = errors.New("unknown resource type")
+	// ErrSuppressionReason is returned when a suppression does not explain why the resource is shared.
+	ErrSuppressionReason = errors.New("suppression reason is required")
+	// ErrSuppressionEmpty is returned when a suppression has no matching criteria.
+	ErrSuppressionEmpty = errors.New("suppression must match on at least one field")
+	// ErrUnknownFailOn is returned when the -fail-on value is not supported.
+	ErrUnknownFailOn = errors.New("unsupported fail-on value")
+	// ErrUnknownSeverity is returned when a severity name is not supported.
+	ErrUnknownSeverity = errors.New("unknown severity")
+	// ErrUnknownPartition is returned when the AWS partition is not supported.
+	ErrUnknownPartition = errors.New("unsupported AWS partition")
+	// ErrMixedPartitions is returned when the regions to scan belong to different AWS partitions.
+	ErrMixedPartitions = errors.New("regions must belong to a single AWS partition")
+	// ErrRegionPartition is returned when the regions to scan do not belong to the selected AWS partition.
+	ErrRegionPartition = errors.New("region is not part of the partition")
+	// ErrInvalidEndpoint is returned when a service endpoint override is not a service=url pair.
+	ErrInvalidEndpoint = errors.New("invalid service endpoint, expected service=url")
+	// ErrInvalidEndpointURL is returned when an endpoint is not an absolute URL.
+	ErrInvalidEndpointURL = errors.New("invalid endpoint URL, expected scheme://host")
+	// ErrWebIdentity is returned when a web identity token file is set without a role ARN, or the other way around.
+	ErrWebIdentity = errors.New("a web identity token file and role ARN must be set together")
+	// ErrUnknownRetryMode is returned when the requested retry mode is not supported.
+	ErrUnknownRetryMode = errors.New("unsupported retry mode")
+	// ErrUnknownOutput is returned when the requested output format is not supported.
+	ErrUnknownOutput = errors.New("unsupported output format")
 )
 
 // GetLogger returns a slog.Logger configured with the given output and log level.
@@ -49,20 +80,6 @@ func GetLogger(output io.Writer, verbose *bool) *slog.Logger {
 	return logger
 }
 
-// PrepareOutput returns a pretty-printed JSON byte slice from a list of Result objects.
-func PrepareOutput(output []Result) ([]byte, error) {
-	marshal, err := json.MarshalIndent(struct {
-		Results []Result `json:"results"`
-	}{
-		Results: output,
-	}, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal output, %w", err)
-	}
-
-	return marshal, nil
-}
-
 // GetSupportedScanners returns supported AWS scanner names.
 func GetSupportedScanners() []string {
 	return []string{
@@ -70,6 +87,11 @@ func GetSupportedScanners() []string {
 		SnapshotEBS.String(),
 		DocumentSSM.String(),
 		SnapshotRDS.String(),
+		LayerLambda.String(),
+		SnapshotRedshift.String(),
+		SnapshotDocDB.String(),
+		SnapshotNeptune.String(),
+		RepositoryECR.String(),
 	}
 }
 
@@ -87,6 +109,16 @@ func GetRunners(input []string) []RunnerType {
 			uniq[DocumentSSM] = struct{}{}
 		case strings.EqualFold(scan, SnapshotRDS.String()):
 			uniq[SnapshotRDS] = struct{}{}
+		case strings.EqualFold(scan, LayerLambda.String()):
+			uniq[LayerLambda] = struct{}{}
+		case strings.EqualFold(scan, SnapshotRedshift.String()):
+			uniq[SnapshotRedshift] = struct{}{}
+		case strings.EqualFold(scan, SnapshotDocDB.String()):
+			uniq[SnapshotDocDB] = struct{}{}
+		case strings.EqualFold(scan, SnapshotNeptune.String()):
+			uniq[SnapshotNeptune] = struct{}{}
+		case strings.EqualFold(scan, RepositoryECR.String()):
+			uniq[RepositoryECR] = struct{}{}
 		default:
 			slog.Debug("invalid scan type", slog.String("type", scan))
 		}
@@ -104,7 +136,23 @@ func GetRunners(input []string) []RunnerType {
 	return output
 }
 
-func uniqRegions(input []string) []string {
+// tagsToMap converts AWS tags to a map, or nil when there are none.
+// Every service has its own tag type, kv returns the key and value of one of them.
+func tagsToMap[T any](tags []T, kv func(T) (*string, *string)) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	output := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		key, value := kv(tag)
+		output[aws.ToString(key)] = aws.ToString(value)
+	}
+
+	return output
+}
+
+func uniqStrings(input []string) []string {
 	uniq := make(map[string]struct{})
 	for _, region := range input {
 		uniq[region] = struct{}{}
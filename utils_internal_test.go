@@ -0,0 +1,46 @@
+// Copyright 2025 variHQ OÜ
+// SPDX-License-Identifier: BSD-3-Clause
+
+package spark
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func Test_tagsToMap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tags []types.Tag
+		want map[string]string
+	}{
+		{
+			name: "no tags",
+			tags: nil,
+			want: nil,
+		},
+		{
+			name: "tags are keyed by name",
+			tags: []types.Tag{
+				{Key: aws.String("team"), Value: aws.String("platform")},
+				{Key: aws.String("empty"), Value: nil},
+			},
+			want: map[string]string{"team": "platform", "empty": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tagsToMap(tt.tags, ec2Tag)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tagsToMap() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
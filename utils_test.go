@@ -105,13 +105,13 @@ func Test_PrepareOutput(t *testing.T) {
 
 	tests := []struct {
 		name    string
-		output  []spark.Result
+		output  *spark.Report
 		want    []byte
 		wantErr bool
 	}{
 		{
 			name:   "empty results list",
-			output: []spark.Result{},
+			output: &spark.Report{Results: []spark.Result{}},
 			want: []byte{
 				123,
 				10,
@@ -135,6 +135,36 @@ func Test_PrepareOutput(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "report with failures",
+			output: &spark.Report{
+				Results: []spark.Result{},
+				Failures: []spark.Failure{
+					{
+						Account: "42",
+						Class:   spark.FailureAccessDenied,
+						Code:    "AccessDenied",
+						Error:   "denied",
+						Region:  "eu-west-1",
+						RType:   spark.SnapshotRDS,
+					},
+				},
+			},
+			want: []byte(`{
+  "results": [],
+  "errors": [
+    {
+      "account": "42",
+      "class": "accessDenied",
+      "code": "AccessDenied",
+      "error": "denied",
+      "region": "eu-west-1",
+      "type": "snapshotsRDS"
+    }
+  ]
+}`),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -175,13 +205,23 @@ func Test_GetRunners(t *testing.T) {
 				"DocumentSSM",
 				"snapshotsEBS",
 				"snapshotsRDS",
+				"layersLambda",
+				"snapshotsRedshift",
+				"snapshotsDocDB",
+				"SNAPSHOTSNEPTUNE",
+				"repositoriesECR",
 				"42",
 			},
 			want: []spark.RunnerType{
 				spark.ImageAMI,
 				spark.DocumentSSM,
+				spark.LayerLambda,
+				spark.RepositoryECR,
+				spark.SnapshotDocDB,
 				spark.SnapshotEBS,
+				spark.SnapshotNeptune,
 				spark.SnapshotRDS,
+				spark.SnapshotRedshift,
 			},
 		},
 	}
@@ -224,7 +264,7 @@ func Test_Spinner(t *testing.T) {
 func TestGetSupportedScanners(t *testing.T) {
 	t.Parallel()
 
-	if got := spark.GetSupportedScanners(); !reflect.DeepEqual(len(got), 4) {
-		t.Errorf("GetSupportedScanners() = %v, want %v", got, 4)
+	if got := spark.GetSupportedScanners(); !reflect.DeepEqual(len(got), 9) {
+		t.Errorf("GetSupportedScanners() = %v, want %v", got, 9)
 	}
 }